@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"time"
 )
 
@@ -12,9 +13,10 @@ const bearerToken string = "AAAAAAAAAAAAAAAAAAAAAPYXBAAAAAAACLXUNDekMxqa8h%2F40K
 
 // RequestAPI get JSON from frontend API and decodes it
 func (s *Scraper) RequestAPI(req *http.Request, target interface{}) error {
-	s.wg.Wait()
-	if s.delay > 0 {
-		defer s.delayRequest()
+	if delay := s.delay; delay > 0 {
+		// requests are serialized, the next one waits for delay after this one finishes
+		s.pace.Lock()
+		defer s.delayRequest(delay)
 	}
 
 	if err := s.prepareRequest(req); err != nil {
@@ -30,11 +32,10 @@ func (s *Scraper) RequestAPI(req *http.Request, target interface{}) error {
 	return s.handleResponse(resp, target)
 }
 
-func (s *Scraper) delayRequest() {
-	s.wg.Add(1)
+func (s *Scraper) delayRequest(delay int64) {
 	go func() {
-		time.Sleep(time.Second * time.Duration(s.delay))
-		s.wg.Done()
+		time.Sleep(time.Second * time.Duration(delay))
+		s.pace.Unlock()
 	}()
 }
 
@@ -47,15 +48,21 @@ func (s *Scraper) prepareRequest(req *http.Request) error {
 		}
 	}
 
-	s.setAuthorizationHeader(req)
+	if err := s.setAuthorizationHeader(req); err != nil {
+		return err
+	}
 	s.setCSRFToken(req)
 
 	return nil
 }
 
+// setGuestToken gets a new guest token when it's missing or expired. Concurrent
+// requests wait for the one getting it instead of getting their own.
 func (s *Scraper) setGuestToken(req *http.Request) error {
-	if !s.IsGuestToken() || s.guestCreatedAt.Before(time.Now().Add(-time.Hour*3)) {
-		if err := s.GetGuestToken(); err != nil {
+	s.guest.Lock()
+	defer s.guest.Unlock()
+	if s.guestToken == "" || s.guestCreatedAt.Before(time.Now().Add(-time.Hour*3)) {
+		if err := s.getGuestToken(); err != nil {
 			return err
 		}
 	}
@@ -63,12 +70,17 @@ func (s *Scraper) setGuestToken(req *http.Request) error {
 	return nil
 }
 
-func (s *Scraper) setAuthorizationHeader(req *http.Request) {
+func (s *Scraper) setAuthorizationHeader(req *http.Request) error {
 	if s.oAuthToken != "" && s.oAuthSecret != "" {
-		req.Header.Set("Authorization", s.sign(req.Method, req.URL))
+		authorization, err := s.sign(req)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", authorization)
 	} else {
 		req.Header.Set("Authorization", "Bearer "+s.bearerToken)
 	}
+	return nil
 }
 
 func (s *Scraper) setCSRFToken(req *http.Request) {
@@ -86,23 +98,42 @@ func (s *Scraper) handleResponse(resp *http.Response, target interface{}) error
 		return err
 	}
 
+	if s.rawResponse != nil {
+		s.rawResponse(resp, content)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("response status %s: %s", resp.Status, content)
 	}
 
 	if resp.Header.Get("X-Rate-Limit-Remaining") == "0" {
+		s.guest.Lock()
 		s.guestToken = ""
+		s.guest.Unlock()
 	}
 
 	if target == nil {
 		return nil
 	}
 
-	return json.Unmarshal(content, target)
+	if err := json.Unmarshal(content, target); err != nil {
+		return err
+	}
+	if s.rawPayloads {
+		keepRawPayloads(reflect.ValueOf(target))
+	}
+	return nil
 }
 
 // GetGuestToken from Twitter API
 func (s *Scraper) GetGuestToken() error {
+	s.guest.Lock()
+	defer s.guest.Unlock()
+	return s.getGuestToken()
+}
+
+// getGuestToken must be called with the guest lock held.
+func (s *Scraper) getGuestToken() error {
 	req, err := http.NewRequest("POST", "https://api.twitter.com/1.1/guest/activate.json", nil)
 	if err != nil {
 		return err
@@ -137,6 +168,8 @@ func (s *Scraper) GetGuestToken() error {
 }
 
 func (s *Scraper) ClearGuestToken() error {
+	s.guest.Lock()
+	defer s.guest.Unlock()
 	s.guestToken = ""
 	s.guestCreatedAt = time.Time{}
 
@@ -0,0 +1,57 @@
+package twitterscraper
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRequestAPIConcurrentGuestToken(t *testing.T) {
+	var activations int32
+	scraper := New()
+	scraper.client.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{}`
+		if strings.HasSuffix(req.URL.Path, "/guest/activate.json") {
+			atomic.AddInt32(&activations, 1)
+			body = `{"guest_token": "1"}`
+		} else if req.Header.Get("X-Guest-Token") != "1" {
+			body = `{"missing": true}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest("GET", "https://twitter.com/i/api/graphql/x/UserTweets", nil)
+			var response struct {
+				Missing bool `json:"missing"`
+			}
+			if err := scraper.RequestAPI(req, &response); err != nil {
+				t.Error(err)
+			} else if response.Missing {
+				t.Error("Expected request with the guest token")
+			}
+		}()
+	}
+	wg.Wait()
+	if activations != 1 {
+		t.Errorf("Expected guest token is got once for concurrent requests, got %d", activations)
+	}
+}
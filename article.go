@@ -0,0 +1,333 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+type noteTweetResult struct {
+	ID        string         `json:"id"`
+	Text      string         `json:"text"`
+	EntitySet legacyEntities `json:"entity_set"`
+	RichText  struct {
+		RichTextTags []struct {
+			FromIndex     int      `json:"from_index"`
+			ToIndex       int      `json:"to_index"`
+			RichTextTypes []string `json:"richtext_types"`
+		} `json:"richtext_tags"`
+	} `json:"richtext"`
+	Media struct {
+		InlineMedia []struct {
+			MediaID string `json:"media_id"`
+			Index   int    `json:"index"`
+		} `json:"inline_media"`
+	} `json:"media"`
+}
+
+// apply replaces the truncated text and entities of a legacy tweet with the note ones.
+func (note *noteTweetResult) apply(tweet *legacyTweet) {
+	tweet.FullText = note.Text
+	media := tweet.Entities.Media
+	tweet.Entities = note.EntitySet
+	tweet.Entities.Media = media
+	start := 0
+	if len(tweet.DisplayTextRange) == 2 {
+		start = tweet.DisplayTextRange[0]
+	}
+	// display range is counted in code points like the entity indices
+	tweet.DisplayTextRange = []int{start, utf8.RuneCountInString(note.Text)}
+}
+
+func (note *noteTweetResult) parse() *NoteTweet {
+	noteTweet := &NoteTweet{ID: note.ID}
+	for _, tag := range note.RichText.RichTextTags {
+		noteTweet.RichText = append(noteTweet.RichText, RichTextTag{
+			Indices: [2]int{tag.FromIndex, tag.ToIndex},
+			Types:   tag.RichTextTypes,
+		})
+	}
+	for _, media := range note.Media.InlineMedia {
+		noteTweet.InlineMedia = append(noteTweet.InlineMedia, InlineMedia{
+			MediaID: media.MediaID,
+			Index:   media.Index,
+		})
+	}
+	return noteTweet
+}
+
+type articleMedia struct {
+	MediaID   string `json:"media_id"`
+	MediaKey  string `json:"media_key"`
+	MediaInfo struct {
+		OriginalImgURL    string `json:"original_img_url"`
+		OriginalImgWidth  int    `json:"original_img_width"`
+		OriginalImgHeight int    `json:"original_img_height"`
+	} `json:"media_info"`
+}
+
+func (media *articleMedia) parse() ArticleImage {
+	return ArticleImage{
+		ID:       media.MediaID,
+		MediaKey: media.MediaKey,
+		URL:      media.MediaInfo.OriginalImgURL,
+		Width:    media.MediaInfo.OriginalImgWidth,
+		Height:   media.MediaInfo.OriginalImgHeight,
+	}
+}
+
+type articleEntityValue struct {
+	Type string `json:"type"`
+	Data struct {
+		URL        string `json:"url"`
+		TweetID    string `json:"tweetId"`
+		MediaItems []struct {
+			MediaID string `json:"mediaId"`
+		} `json:"mediaItems"`
+	} `json:"data"`
+}
+
+type articleEntityMap map[string]articleEntityValue
+
+// UnmarshalJSON decodes the entity map from a list of key and value pairs
+// returned by GraphQL or from an object as stored by the editor.
+func (entityMap *articleEntityMap) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		var list []struct {
+			Key   string             `json:"key"`
+			Value articleEntityValue `json:"value"`
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*entityMap = make(articleEntityMap, len(list))
+		for _, item := range list {
+			(*entityMap)[item.Key] = item.Value
+		}
+		return nil
+	}
+	var object map[string]articleEntityValue
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*entityMap = object
+	return nil
+}
+
+type articleResult struct {
+	RestID       string        `json:"rest_id"`
+	Title        string        `json:"title"`
+	PreviewText  string        `json:"preview_text"`
+	CoverMedia   *articleMedia `json:"cover_media"`
+	ContentState struct {
+		Blocks []struct {
+			Type              string `json:"type"`
+			Text              string `json:"text"`
+			InlineStyleRanges []struct {
+				Offset int    `json:"offset"`
+				Length int    `json:"length"`
+				Style  string `json:"style"`
+			} `json:"inlineStyleRanges"`
+			EntityRanges []struct {
+				Key    int `json:"key"`
+				Offset int `json:"offset"`
+				Length int `json:"length"`
+			} `json:"entityRanges"`
+		} `json:"blocks"`
+		EntityMap articleEntityMap `json:"entityMap"`
+	} `json:"content_state"`
+	MediaEntities  []articleMedia `json:"media_entities"`
+	LifecycleState struct {
+		ModifiedAtSecs int64 `json:"modified_at_secs"`
+	} `json:"lifecycle_state"`
+	Metadata struct {
+		FirstPublishedAtSecs int64 `json:"first_published_at_secs"`
+	} `json:"metadata"`
+}
+
+func (result *articleResult) parse() *Article {
+	if result.RestID == "" {
+		return nil
+	}
+	article := &Article{
+		ID:          result.RestID,
+		Title:       result.Title,
+		PreviewText: result.PreviewText,
+	}
+	if result.CoverMedia != nil {
+		cover := result.CoverMedia.parse()
+		article.CoverImage = &cover
+	}
+	for _, media := range result.MediaEntities {
+		article.Media = append(article.Media, media.parse())
+	}
+	if result.Metadata.FirstPublishedAtSecs > 0 {
+		article.PublishedAt = time.Unix(result.Metadata.FirstPublishedAtSecs, 0).UTC()
+	}
+	if result.LifecycleState.ModifiedAtSecs > 0 {
+		article.ModifiedAt = time.Unix(result.LifecycleState.ModifiedAtSecs, 0).UTC()
+	}
+
+	for _, b := range result.ContentState.Blocks {
+		block := ArticleBlock{Type: b.Type, Text: b.Text}
+		for _, style := range b.InlineStyleRanges {
+			block.Styles = append(block.Styles, ArticleStyle{
+				Offset: style.Offset,
+				Length: style.Length,
+				Style:  style.Style,
+			})
+		}
+		for _, entityRange := range b.EntityRanges {
+			value, ok := result.ContentState.EntityMap[strconv.Itoa(entityRange.Key)]
+			if !ok {
+				continue
+			}
+			entity := ArticleEntity{
+				Offset:  entityRange.Offset,
+				Length:  entityRange.Length,
+				Type:    value.Type,
+				URL:     value.Data.URL,
+				TweetID: value.Data.TweetID,
+			}
+			for _, item := range value.Data.MediaItems {
+				entity.MediaIDs = append(entity.MediaIDs, item.MediaID)
+			}
+			block.Entities = append(block.Entities, entity)
+		}
+		article.Blocks = append(article.Blocks, block)
+	}
+	return article
+}
+
+var markdownStyles = map[string]string{
+	"Bold":          "**",
+	"Italic":        "_",
+	"Strikethrough": "~~",
+}
+
+var markdownPrefixes = map[string]string{
+	"header-one":          "# ",
+	"header-two":          "## ",
+	"header-three":        "### ",
+	"unordered-list-item": "- ",
+	"ordered-list-item":   "1. ",
+	"blockquote":          "> ",
+}
+
+// Markdown renders the article with its title, cover image, formatting, links and embeds.
+func (article *Article) Markdown() string {
+	var sb strings.Builder
+	if article.Title != "" {
+		sb.WriteString("# " + article.Title + "\n\n")
+	}
+	if article.CoverImage != nil && article.CoverImage.URL != "" {
+		sb.WriteString("![](" + article.CoverImage.URL + ")\n\n")
+	}
+
+	for i, block := range article.Blocks {
+		if i > 0 {
+			// items of the same list are kept together
+			if strings.HasSuffix(block.Type, "list-item") && block.Type == article.Blocks[i-1].Type {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("\n\n")
+			}
+		}
+		switch block.Type {
+		case "atomic":
+			sb.WriteString(article.markdownEmbed(block))
+		case "code-block":
+			sb.WriteString("```\n" + block.Text + "\n```")
+		default:
+			sb.WriteString(markdownPrefixes[block.Type] + block.markdownText())
+		}
+	}
+	return strings.TrimSpace(sb.String()) + "\n"
+}
+
+// markdownEmbed renders media, tweets and dividers of an atomic block.
+func (article *Article) markdownEmbed(block ArticleBlock) string {
+	var parts []string
+	for _, entity := range block.Entities {
+		switch entity.Type {
+		case "MEDIA":
+			for _, id := range entity.MediaIDs {
+				for _, media := range article.Media {
+					if media.ID == id {
+						parts = append(parts, "![]("+media.URL+")")
+					}
+				}
+			}
+		case "TWEET":
+			parts = append(parts, "https://twitter.com/i/status/"+entity.TweetID)
+		case "DIVIDER":
+			parts = append(parts, "---")
+		case "LINK":
+			parts = append(parts, entity.URL)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+type markdownMarker struct {
+	offset int
+	// closing markers go before opening ones at the same offset
+	closing bool
+	order   int
+	text    string
+}
+
+// markdownText returns block text with inline styles and links as Markdown.
+func (block ArticleBlock) markdownText() string {
+	var markers []markdownMarker
+	add := func(offset, length int, open, close string) {
+		order := len(markers)
+		markers = append(markers,
+			markdownMarker{offset: offset, order: order, text: open},
+			markdownMarker{offset: offset + length, closing: true, order: -order, text: close})
+	}
+	for _, style := range block.Styles {
+		if mark, ok := markdownStyles[style.Style]; ok && style.Length > 0 {
+			add(style.Offset, style.Length, mark, mark)
+		}
+	}
+	for _, entity := range block.Entities {
+		if entity.Type == "LINK" && entity.URL != "" && entity.Length > 0 {
+			add(entity.Offset, entity.Length, "[", fmt.Sprintf("](%s)", entity.URL))
+		}
+	}
+	if len(markers) == 0 {
+		return block.Text
+	}
+	sort.SliceStable(markers, func(i, j int) bool {
+		if markers[i].offset != markers[j].offset {
+			return markers[i].offset < markers[j].offset
+		}
+		if markers[i].closing != markers[j].closing {
+			return markers[i].closing
+		}
+		return markers[i].order < markers[j].order
+	})
+
+	text := utf16.Encode([]rune(block.Text))
+	var sb strings.Builder
+	pos := 0
+	for _, marker := range markers {
+		offset := marker.offset
+		if offset > len(text) {
+			offset = len(text)
+		}
+		if offset > pos {
+			sb.WriteString(string(utf16.Decode(text[pos:offset])))
+			pos = offset
+		}
+		sb.WriteString(marker.text)
+	}
+	sb.WriteString(string(utf16.Decode(text[pos:])))
+	return sb.String()
+}
@@ -0,0 +1,21 @@
+package twitterscraper
+
+import "testing"
+
+func TestNoteTweetApplyDisplayRange(t *testing.T) {
+	tweet := legacyTweet{FullText: "truncated", DisplayTextRange: []int{5, 9}}
+	note := noteTweetResult{Text: "@x hi 👋 #go"}
+	note.EntitySet.Hashtags = []legacyTextEntity{{Text: "go", Indices: []int{8, 11}}}
+	note.apply(&tweet)
+
+	if tweet.FullText != note.Text {
+		t.Errorf("Expected full text %q, got %q", note.Text, tweet.FullText)
+	}
+	// the emoji takes one code point and two UTF-16 code units
+	if tweet.DisplayTextRange[0] != 5 || tweet.DisplayTextRange[1] != 11 {
+		t.Errorf("Expected display range [5 11], got %v", tweet.DisplayTextRange)
+	}
+	if len(tweet.Entities.Hashtags) != 1 || tweet.Entities.Hashtags[0].Indices[1] != tweet.DisplayTextRange[1] {
+		t.Errorf("Expected note entities end at the display range, got %v", tweet.Entities.Hashtags)
+	}
+}
@@ -0,0 +1,47 @@
+package twitterscraper_test
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestArticleMarkdown(t *testing.T) {
+	article := twitterscraper.Article{
+		Title:      "Title",
+		CoverImage: &twitterscraper.ArticleImage{URL: "https://pbs.twimg.com/media/cover.jpg"},
+		Blocks: []twitterscraper.ArticleBlock{
+			{Type: "header-two", Text: "Intro"},
+			{
+				Type: "unstyled",
+				Text: "Привет world, read more",
+				Styles: []twitterscraper.ArticleStyle{
+					{Offset: 0, Length: 6, Style: "Bold"},
+					{Offset: 7, Length: 5, Style: "Italic"},
+				},
+				Entities: []twitterscraper.ArticleEntity{
+					{Offset: 14, Length: 9, Type: "LINK", URL: "https://example.com"},
+				},
+			},
+			{Type: "unordered-list-item", Text: "one"},
+			{Type: "unordered-list-item", Text: "two"},
+			{Type: "atomic", Text: " ", Entities: []twitterscraper.ArticleEntity{{Type: "MEDIA", MediaIDs: []string{"1"}}}},
+			{Type: "atomic", Text: " ", Entities: []twitterscraper.ArticleEntity{{Type: "DIVIDER"}}},
+			{Type: "atomic", Text: " ", Entities: []twitterscraper.ArticleEntity{{Type: "TWEET", TweetID: "20"}}},
+		},
+		Media: []twitterscraper.ArticleImage{{ID: "1", URL: "https://pbs.twimg.com/media/1.jpg"}},
+	}
+
+	expected := "# Title\n\n" +
+		"![](https://pbs.twimg.com/media/cover.jpg)\n\n" +
+		"## Intro\n\n" +
+		"**Привет** _world_, [read more](https://example.com)\n\n" +
+		"- one\n" +
+		"- two\n\n" +
+		"![](https://pbs.twimg.com/media/1.jpg)\n\n" +
+		"---\n\n" +
+		"https://twitter.com/i/status/20\n"
+	if markdown := article.Markdown(); markdown != expected {
+		t.Errorf("Unexpected markdown:\n%s\nexpected:\n%s", markdown, expected)
+	}
+}
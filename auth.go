@@ -2,16 +2,11 @@ package twitterscraper
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha1"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/cookiejar"
-	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
@@ -368,6 +363,9 @@ func (s *Scraper) LoginOpenAccount() (OpenAccount, error) {
 			if s.oAuthToken == "" || s.oAuthSecret == "" {
 				return OpenAccount{}, fmt.Errorf("auth error: %v", "Token or Secret is empty")
 			}
+			// Open account tokens are issued for the built-in app only.
+			s.consumerKey = appConsumerKey
+			s.consumerSecret = appConsumerSecret
 			s.isLogged = true
 			s.isOpenAccount = true
 			return OpenAccount{
@@ -399,7 +397,7 @@ func (s *Scraper) Logout() error {
 
 	s.isLogged = false
 	s.isOpenAccount = false
-	s.guestToken = ""
+	s.ClearGuestToken()
 	s.oAuthToken = ""
 	s.oAuthSecret = ""
 	s.client.Jar, _ = cookiejar.New(nil)
@@ -466,44 +464,3 @@ func (s *Scraper) SetAuthToken(token AuthToken) {
 
 	s.SetCookies(cookies)
 }
-
-func (s *Scraper) sign(method string, ref *url.URL) string {
-	m := make(map[string]string)
-	m["oauth_consumer_key"] = appConsumerKey
-	m["oauth_nonce"] = "0"
-	m["oauth_signature_method"] = "HMAC-SHA1"
-	m["oauth_timestamp"] = strconv.FormatInt(time.Now().Unix(), 10)
-	m["oauth_token"] = s.oAuthToken
-
-	key := []byte(appConsumerSecret + "&" + s.oAuthSecret)
-	h := hmac.New(sha1.New, key)
-
-	query := ref.Query()
-	for k, v := range m {
-		query.Set(k, v)
-	}
-
-	req := []string{method, ref.Scheme + "://" + ref.Host + ref.Path, query.Encode()}
-	var reqBuf bytes.Buffer
-	for _, value := range req {
-		if reqBuf.Len() > 0 {
-			reqBuf.WriteByte('&')
-		}
-		reqBuf.WriteString(url.QueryEscape(value))
-	}
-	h.Write(reqBuf.Bytes())
-
-	m["oauth_signature"] = base64.StdEncoding.EncodeToString(h.Sum(nil))
-
-	var b bytes.Buffer
-	for k, v := range m {
-		if b.Len() > 0 {
-			b.WriteByte(',')
-		}
-		b.WriteString(k)
-		b.WriteByte('=')
-		b.WriteString(url.QueryEscape(v))
-	}
-
-	return "OAuth " + b.String()
-}
@@ -0,0 +1,275 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+// BackfillOptions configures BackfillSearch.
+type BackfillOptions struct {
+	// Since is the start of the time range, it is required.
+	Since time.Time
+	// Until is the end of the time range, now by default.
+	Until time.Time
+	// Window is the size of the first searched window, 24 hours by default.
+	Window time.Duration
+	// MinWindow and MaxWindow bound the adapted window size,
+	// 1 minute and 30 days by default.
+	MinWindow time.Duration
+	MaxWindow time.Duration
+	// WindowLimit is the number of tweets after which a window is saturated
+	// and searched again up to its oldest tweet, 200 by default.
+	WindowLimit int
+	// Chronological streams tweets from oldest to newest instead of newest to oldest.
+	Chronological bool
+	// OnProgress is called after every searched window.
+	OnProgress func(progress BackfillProgress)
+}
+
+// BackfillProgress describes a searched window of BackfillSearch.
+type BackfillProgress struct {
+	Since     time.Time
+	Until     time.Time
+	Tweets    int
+	Total     int
+	Saturated bool
+	// Window is the size of the next window.
+	Window time.Duration
+}
+
+// BackfillSearch collects tweets for query within a time range beyond the search depth limit.
+// The range is split into since_time/until_time windows searched in latest mode, windows
+// are narrowed when they saturate and widened when they are sparse. Tweets are deduplicated.
+func (s *Scraper) BackfillSearch(ctx context.Context, query string, options BackfillOptions) <-chan *TweetResult {
+	return s.backfillSearch(ctx, query, options, s.fetchLatestSearchTweets)
+}
+
+func (s *Scraper) backfillSearch(ctx context.Context, query string, options BackfillOptions, fetchFunc fetchTweetFunc) <-chan *TweetResult {
+	if options.Until.IsZero() {
+		options.Until = time.Now()
+	}
+	if options.MinWindow <= 0 {
+		options.MinWindow = time.Minute
+	}
+	if options.MaxWindow <= 0 {
+		options.MaxWindow = 30 * 24 * time.Hour
+	}
+	if options.Window <= 0 {
+		options.Window = 24 * time.Hour
+	}
+	if options.WindowLimit <= 0 {
+		options.WindowLimit = 200
+	}
+
+	channel := make(chan *TweetResult)
+	b := &backfill{
+		ctx:       ctx,
+		fetchFunc: fetchFunc,
+		query:     query,
+		options:   options,
+		window:    options.Window,
+		seen:      newSeenIDs(0),
+		channel:   channel,
+	}
+	b.clampWindow()
+
+	go func() {
+		defer close(channel)
+		var err error
+		if options.Chronological {
+			err = b.forward()
+		} else {
+			err = b.backward()
+		}
+		if err != nil && ctx.Err() == nil {
+			channel <- &TweetResult{Error: err}
+		}
+	}()
+	return channel
+}
+
+type backfill struct {
+	ctx       context.Context
+	fetchFunc fetchTweetFunc
+	query     string
+	options   BackfillOptions
+	window    time.Duration
+	seen      *seenIDs
+	total     int
+	channel   chan *TweetResult
+}
+
+// backward walks the range from Until to Since, emitting tweets as they are fetched.
+func (b *backfill) backward() error {
+	until := b.options.Until
+	for until.After(b.options.Since) {
+		since := until.Add(-b.window)
+		if since.Before(b.options.Since) {
+			since = b.options.Since
+		}
+		tweets, saturated, err := b.search(since, until)
+		if err != nil {
+			return err
+		}
+		if err := b.emit(tweets); err != nil {
+			return err
+		}
+		if saturated {
+			until = continueUntil(tweets, until)
+		} else {
+			until = since
+		}
+	}
+	return nil
+}
+
+// forward walks the range from Since to Until window by window.
+func (b *backfill) forward() error {
+	since := b.options.Since
+	for since.Before(b.options.Until) {
+		until := since.Add(b.window)
+		if until.After(b.options.Until) {
+			until = b.options.Until
+		}
+		if err := b.forwardWindow(since, until); err != nil {
+			return err
+		}
+		since = until
+	}
+	return nil
+}
+
+// forwardWindow emits tweets of a window from oldest to newest. A saturated window
+// is searched again in two halves, each emitted once it is complete, so only
+// one sub-window is held at a time. A saturated window of MinWindow is completed
+// up to its oldest fetched tweet before its tweets are emitted.
+func (b *backfill) forwardWindow(since, until time.Time) error {
+	if !since.Before(until) {
+		return nil
+	}
+	tweets, saturated, err := b.search(since, until)
+	if err != nil {
+		return err
+	}
+	if saturated && until.Sub(since) > b.options.MinWindow {
+		size := until.Sub(since) / 2
+		if size < b.options.MinWindow {
+			size = b.options.MinWindow
+		}
+		for since.Before(until) {
+			end := since.Add(size)
+			if end.After(until) {
+				end = until
+			}
+			if err := b.forwardWindow(since, end); err != nil {
+				return err
+			}
+			since = end
+		}
+		return nil
+	}
+	if saturated {
+		if err := b.forwardWindow(since, continueUntil(tweets, until)); err != nil {
+			return err
+		}
+	}
+	for i, j := 0, len(tweets)-1; i < j; i, j = i+1, j-1 {
+		tweets[i], tweets[j] = tweets[j], tweets[i]
+	}
+	return b.emit(tweets)
+}
+
+// search fetches up to WindowLimit tweets of a window not emitted yet, newest first.
+// They are remembered as seen only when emitted, since a saturated window may be searched again.
+func (b *backfill) search(since, until time.Time) ([]*Tweet, bool, error) {
+	query := fmt.Sprintf("%s since_time:%d until_time:%d", b.query, since.Unix(), until.Unix())
+	var tweets []*Tweet
+	var cursor string
+	fetched := make(map[string]struct{})
+	saturated := true
+	for len(tweets) < b.options.WindowLimit {
+		if err := b.ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		page, next, err := b.fetchFunc(query, 50, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, tweet := range page {
+			if _, ok := fetched[tweet.ID]; ok || b.seen.has(tweet.ID) {
+				continue
+			}
+			fetched[tweet.ID] = struct{}{}
+			tweets = append(tweets, tweet)
+		}
+		if len(page) == 0 || next == "" || next == cursor {
+			saturated = false
+			break
+		}
+		cursor = next
+	}
+	sort.SliceStable(tweets, func(i, j int) bool {
+		return snowflake.Compare(tweets[i].ID, tweets[j].ID) > 0
+	})
+
+	if saturated {
+		b.window /= 2
+	} else if len(tweets) < b.options.WindowLimit/4 {
+		b.window *= 2
+	}
+	b.clampWindow()
+	if b.options.OnProgress != nil {
+		b.options.OnProgress(BackfillProgress{
+			Since:     since,
+			Until:     until,
+			Tweets:    len(tweets),
+			Total:     b.total + len(tweets),
+			Saturated: saturated,
+			Window:    b.window,
+		})
+	}
+	return tweets, saturated, nil
+}
+
+// emit sends tweets not emitted yet, windows overlap by a second after a saturated search.
+func (b *backfill) emit(tweets []*Tweet) error {
+	for _, tweet := range tweets {
+		if !b.seen.add(tweet.ID) {
+			continue
+		}
+		b.total++
+		select {
+		case b.channel <- &TweetResult{Tweet: *tweet}:
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (b *backfill) clampWindow() {
+	if b.window < b.options.MinWindow {
+		b.window = b.options.MinWindow
+	}
+	if b.window > b.options.MaxWindow {
+		b.window = b.options.MaxWindow
+	}
+}
+
+// continueUntil returns the end of the window left after a saturated search,
+// it includes the second of the oldest fetched tweet since windows have second precision.
+func continueUntil(tweets []*Tweet, until time.Time) time.Time {
+	next := until.Add(-time.Second)
+	if len(tweets) > 0 {
+		if oldest := tweets[len(tweets)-1].TimeParsed; !oldest.IsZero() {
+			if end := oldest.Truncate(time.Second).Add(time.Second); end.Before(until) {
+				next = end
+			}
+		}
+	}
+	return next
+}
@@ -0,0 +1,115 @@
+package twitterscraper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+// fakeSearch serves tweets posted every minute from start for since_time/until_time
+// queries, newest first, and records the searched windows.
+func fakeSearch(start time.Time, count int, windows *[]time.Duration) fetchTweetFunc {
+	return func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		var sinceUnix, untilUnix int64
+		if _, err := fmt.Sscanf(query, "q since_time:%d until_time:%d", &sinceUnix, &untilUnix); err != nil {
+			return nil, "", err
+		}
+		since, until := time.Unix(sinceUnix, 0), time.Unix(untilUnix, 0)
+		if cursor == "" {
+			*windows = append(*windows, until.Sub(since))
+		}
+		var matched []*Tweet
+		for i := count - 1; i >= 0; i-- {
+			created := start.Add(time.Duration(i) * time.Minute)
+			if !created.Before(since) && created.Before(until) {
+				matched = append(matched, &Tweet{ID: snowflake.MinID(created), TimeParsed: created})
+			}
+		}
+		offset, _ := strconv.Atoi(cursor)
+		if offset >= len(matched) {
+			return nil, "", nil
+		}
+		end := offset + maxTweetsNbr
+		if end > len(matched) {
+			end = len(matched)
+		}
+		return matched[offset:end], strconv.Itoa(end), nil
+	}
+}
+
+func TestBackfillForward(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		options   BackfillOptions
+		minWindow time.Duration
+	}{
+		{
+			// saturated windows are split down to windows of at most WindowLimit tweets
+			name:      "halving",
+			options:   BackfillOptions{Window: 2 * time.Hour, MinWindow: time.Minute, WindowLimit: 10},
+			minWindow: 7*time.Minute + 30*time.Second,
+		},
+		{
+			// saturated windows of MinWindow are searched again up to their oldest tweet
+			name:      "saturation at min window",
+			options:   BackfillOptions{Window: 2 * time.Hour, MinWindow: 2 * time.Hour, WindowLimit: 10},
+			minWindow: time.Second,
+		},
+	}
+	for _, test := range tests {
+		var windows []time.Duration
+		options := test.options
+		options.Since = start
+		options.Until = start.Add(2 * time.Hour)
+		options.Chronological = true
+		saturated := 0
+		options.OnProgress = func(progress BackfillProgress) {
+			if progress.Saturated {
+				saturated++
+			}
+		}
+
+		var requestsAtFirst int
+		var previous *Tweet
+		n := 0
+		for tweet := range New().backfillSearch(context.Background(), "q", options, fakeSearch(start, 120, &windows)) {
+			if tweet.Error != nil {
+				t.Fatalf("%s: %v", test.name, tweet.Error)
+			}
+			if n == 0 {
+				requestsAtFirst = len(windows)
+			}
+			if expected := start.Add(time.Duration(n) * time.Minute); !tweet.TimeParsed.Equal(expected) {
+				t.Fatalf("%s: expected tweet %d at %v, got %v", test.name, n, expected, tweet.TimeParsed)
+			}
+			if previous != nil && snowflake.Compare(previous.ID, tweet.ID) >= 0 {
+				t.Errorf("%s: expected tweet %s after %s", test.name, tweet.ID, previous.ID)
+			}
+			previous = &tweet.Tweet
+			n++
+		}
+		if n != 120 {
+			t.Errorf("%s: expected 120 tweets, got %d", test.name, n)
+		}
+		if saturated == 0 {
+			t.Errorf("%s: expected saturated windows", test.name)
+		}
+		smallest := windows[0]
+		for _, window := range windows {
+			if window < smallest {
+				smallest = window
+			}
+		}
+		if smallest != test.minWindow {
+			t.Errorf("%s: expected smallest window %v, got %v in %v", test.name, test.minWindow, smallest, windows)
+		}
+		if test.name == "halving" && requestsAtFirst >= len(windows) {
+			t.Errorf("%s: expected first tweets before the whole range is searched, got them after %d of %d windows", test.name, requestsAtFirst, len(windows))
+		}
+	}
+}
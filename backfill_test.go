@@ -0,0 +1,51 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestBackfillSearch(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	since := time.Now().Add(-90 * 24 * time.Hour)
+	windows := 0
+	options := twitterscraper.BackfillOptions{
+		Since:         since,
+		Window:        7 * 24 * time.Hour,
+		WindowLimit:   20,
+		Chronological: true,
+		OnProgress: func(progress twitterscraper.BackfillProgress) {
+			windows++
+		},
+	}
+
+	var previous *twitterscraper.Tweet
+	dupcheck := make(map[string]bool)
+	for tweet := range testScraper.BackfillSearch(context.Background(), "from:x", options) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		if dupcheck[tweet.ID] {
+			t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+		}
+		dupcheck[tweet.ID] = true
+		if tweet.TimeParsed.Before(since) {
+			t.Errorf("Expected tweet %s is not older than since", tweet.ID)
+		}
+		if previous != nil && previous.TimeParsed.After(tweet.TimeParsed) {
+			t.Errorf("Expected tweet %s is not older than previous tweet %s", tweet.ID, previous.ID)
+		}
+		previous = &tweet.Tweet
+	}
+	if len(dupcheck) == 0 {
+		t.Error("Expected tweets are found")
+	}
+	if windows == 0 {
+		t.Error("Expected progress is reported")
+	}
+}
@@ -7,9 +7,16 @@ import (
 
 // GetBookmarks returns channel with tweets from user bookmarks.
 func (s *Scraper) GetBookmarks(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, func(unused string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-		return s.FetchBookmarks(maxTweetsNbr, cursor)
-	})
+	return s.getTweetTimeline(ctx, OperationBookmarks, "", maxTweetsNbr, s.fetchBookmarks)
+}
+
+// IterBookmarks returns iterator over tweets from user bookmarks.
+func (s *Scraper) IterBookmarks(ctx context.Context, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationBookmarks, "", maxTweetsNbr, s.fetchBookmarks)
+}
+
+func (s *Scraper) fetchBookmarks(_ string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.FetchBookmarks(maxTweetsNbr, cursor)
 }
 
 // FetchBookmarks gets bookmarked tweets via the Twitter frontend GraphQL API.
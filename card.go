@@ -0,0 +1,182 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type legacyCard struct {
+	Name          string            `json:"name"`
+	URL           string            `json:"url"`
+	BindingValues cardBindingValues `json:"binding_values"`
+}
+
+type cardBindingValue struct {
+	Type         string `json:"type"`
+	StringValue  string `json:"string_value"`
+	BooleanValue bool   `json:"boolean_value"`
+	ImageValue   *struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	} `json:"image_value"`
+	UserValue *struct {
+		IDStr string `json:"id_str"`
+	} `json:"user_value"`
+}
+
+type cardBindingValues map[string]cardBindingValue
+
+// UnmarshalJSON decodes binding values from a list of key and value pairs
+// returned by GraphQL or from an object returned by v1 timelines.
+func (values *cardBindingValues) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		var list []struct {
+			Key   string           `json:"key"`
+			Value cardBindingValue `json:"value"`
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*values = make(cardBindingValues, len(list))
+		for _, item := range list {
+			(*values)[item.Key] = item.Value
+		}
+		return nil
+	}
+	var object map[string]cardBindingValue
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*values = object
+	return nil
+}
+
+func (card *legacyCard) parse(tw *Tweet) *Card {
+	if card.Name == "" {
+		return nil
+	}
+	parsed := &Card{
+		Name:   card.Name,
+		URL:    card.URL,
+		Values: make(map[string]CardValue, len(card.BindingValues)),
+	}
+	for key, value := range card.BindingValues {
+		cardValue := CardValue{
+			Type:    value.Type,
+			String:  value.StringValue,
+			Boolean: value.BooleanValue,
+			Image:   card.image(key),
+		}
+		if value.UserValue != nil {
+			cardValue.UserID = value.UserValue.IDStr
+		}
+		parsed.Values[key] = cardValue
+	}
+
+	// promoted cards are named like 123456:poll2choice_text_only
+	name := card.Name
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	switch {
+	case strings.HasPrefix(name, "poll") && strings.Contains(name, "choice"):
+		parsed.Poll = card.parsePoll()
+	case name == "summary" || name == "summary_large_image":
+		parsed.LinkPreview = &LinkPreview{
+			Title:       card.string("title"),
+			Description: card.string("description"),
+			Domain:      card.string("vanity_url", "domain"),
+			URL:         card.destination(tw),
+			Image:       card.image("summary_photo_image_original", "thumbnail_image_original", "photo_image_full_size_original", "thumbnail_image_large", "thumbnail_image"),
+		}
+	case name == "player":
+		parsed.Player = &Player{
+			Title:       card.string("title"),
+			Description: card.string("description"),
+			URL:         card.string("player_url"),
+			StreamURL:   card.string("player_stream_url"),
+			Width:       card.int("player_width"),
+			Height:      card.int("player_height"),
+			Image:       card.image("player_image_original", "player_image_large", "player_image"),
+		}
+	case name == "app" || name == "promo_app" || name == "appplayer":
+		rating, _ := strconv.ParseFloat(card.string("app_star_rating"), 64)
+		parsed.App = &AppCard{
+			Name:       card.string("app_name", "title"),
+			AppID:      card.string("app_id", "app_id_iphone", "app_id_googleplay"),
+			Category:   card.string("app_category"),
+			StarRating: rating,
+			Ratings:    card.int("app_num_ratings"),
+			Image:      card.image("thumbnail_image_original", "thumbnail_image"),
+		}
+	}
+	return parsed
+}
+
+func (card *legacyCard) parsePoll() *Poll {
+	poll := &Poll{
+		DurationMinutes: card.int("duration_minutes"),
+		Final:           card.BindingValues["counts_are_final"].BooleanValue,
+	}
+	for i := 1; ; i++ {
+		label, ok := card.BindingValues["choice"+strconv.Itoa(i)+"_label"]
+		if !ok {
+			break
+		}
+		poll.Choices = append(poll.Choices, PollChoice{
+			Label: label.StringValue,
+			Count: card.int("choice" + strconv.Itoa(i) + "_count"),
+		})
+	}
+	poll.EndTime, _ = time.Parse(time.RFC3339, card.string("end_datetime_utc"))
+	poll.LastUpdated, _ = time.Parse(time.RFC3339, card.string("last_updated_datetime_utc"))
+	return poll
+}
+
+// IsOpen reports whether voting is still going on. It's checked against the
+// current time on every call, so a saved poll doesn't report a stale state.
+func (poll *Poll) IsOpen() bool {
+	return !poll.Final && (poll.EndTime.IsZero() || time.Now().Before(poll.EndTime))
+}
+
+// string returns the first non-empty string value of keys.
+func (card *legacyCard) string(keys ...string) string {
+	for _, key := range keys {
+		if value := card.BindingValues[key].StringValue; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (card *legacyCard) int(key string) int {
+	value, _ := strconv.Atoi(card.BindingValues[key].StringValue)
+	return value
+}
+
+// image returns the first image value of keys.
+func (card *legacyCard) image(keys ...string) *CardImage {
+	for _, key := range keys {
+		if image := card.BindingValues[key].ImageValue; image != nil {
+			return &CardImage{URL: image.URL, Width: image.Width, Height: image.Height}
+		}
+	}
+	return nil
+}
+
+// destination resolves the t.co link of the card with URL entities of the tweet.
+func (card *legacyCard) destination(tw *Tweet) string {
+	link := card.string("card_url")
+	if link == "" {
+		link = card.URL
+	}
+	for _, url := range tw.Entities.URLs {
+		if url.URL == link {
+			return url.ExpandedURL
+		}
+	}
+	return link
+}
@@ -0,0 +1,58 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const pollCardJSON = `{
+	"name": "poll2choice_text_only",
+	"url": "https://twitter.com/i/cards/1",
+	"binding_values": [
+		{"key": "choice1_label", "value": {"type": "STRING", "string_value": "Yes"}},
+		{"key": "choice1_count", "value": {"type": "STRING", "string_value": "10"}},
+		{"key": "choice2_label", "value": {"type": "STRING", "string_value": "No"}},
+		{"key": "choice2_count", "value": {"type": "STRING", "string_value": "5"}},
+		{"key": "duration_minutes", "value": {"type": "STRING", "string_value": "1440"}},
+		{"key": "end_datetime_utc", "value": {"type": "STRING", "string_value": "2024-05-02T00:00:00Z"}},
+		{"key": "last_updated_datetime_utc", "value": {"type": "STRING", "string_value": "2024-05-01T12:00:00Z"}},
+		{"key": "counts_are_final", "value": {"type": "BOOLEAN", "boolean_value": false}}
+	]
+}`
+
+func TestParsePoll(t *testing.T) {
+	var card legacyCard
+	if err := json.Unmarshal([]byte(pollCardJSON), &card); err != nil {
+		t.Fatal(err)
+	}
+	expected := &Poll{
+		Choices:         []PollChoice{{Label: "Yes", Count: 10}, {Label: "No", Count: 5}},
+		DurationMinutes: 1440,
+		EndTime:         time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		LastUpdated:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	if poll := card.parsePoll(); !reflect.DeepEqual(poll, expected) {
+		t.Errorf("Expected poll %#v, got %#v", expected, poll)
+	}
+}
+
+func TestPollIsOpen(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		poll     Poll
+		expected bool
+	}{
+		{"ends later", Poll{EndTime: now.Add(time.Hour)}, true},
+		{"ended", Poll{EndTime: now.Add(-time.Hour)}, false},
+		{"final before the end", Poll{EndTime: now.Add(time.Hour), Final: true}, false},
+		{"unknown end", Poll{}, true},
+	}
+	for _, test := range tests {
+		if open := test.poll.IsOpen(); open != test.expected {
+			t.Errorf("%s: IsOpen() = %v, expected %v", test.name, open, test.expected)
+		}
+	}
+}
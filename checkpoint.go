@@ -0,0 +1,204 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Operations used as checkpoint keys by paginated methods.
+const (
+	OperationTweets           = "Tweets"
+	OperationTweetsByUserID   = "TweetsByUserID"
+	OperationTweetsAndReplies = "TweetsAndReplies"
+	OperationMediaTweets      = "MediaTweets"
+	OperationSearchTweets     = "SearchTweets"
+	OperationSearchProfiles   = "SearchProfiles"
+	OperationSearchLists      = "SearchLists"
+	OperationBookmarks        = "Bookmarks"
+	OperationHomeTweets       = "HomeTweets"
+	OperationForYouTweets     = "ForYouTweets"
+	OperationFollowers        = "Followers"
+	OperationFollowing        = "Following"
+	OperationTweetRetweeters  = "TweetRetweeters"
+	OperationTweetQuotes      = "TweetQuotes"
+	OperationTweetLikers      = "TweetLikers"
+	OperationListTweets       = "ListTweets"
+	OperationMentions         = "Mentions"
+)
+
+// CheckpointInterval is the number of items of a page an iterator emits between saves.
+// Saving after every item would rewrite the checkpoint for every tweet or user.
+const CheckpointInterval = 20
+
+// Checkpoint is a saved position of a paginated stream.
+type Checkpoint struct {
+	Operation string `json:"operation"`
+	// Query is the query of the stream, followed by "?" and its encoded
+	// TimelineOptions and search product when they are set.
+	Query string `json:"query"`
+	// Cursor of the page to fetch when the stream is resumed.
+	Cursor string `json:"cursor"`
+	// Offset is the number of items of that page which were already emitted.
+	Offset int `json:"offset"`
+	// Count is the number of items emitted by the stream in total, over all resumes.
+	Count int `json:"count"`
+	// Done is set when the stream reached the end of the timeline.
+	// A done checkpoint is ignored and the stream starts from scratch.
+	Done bool `json:"done"`
+	// SeenIDs are the tweet IDs remembered for deduplication, oldest first.
+	SeenIDs []string `json:"seen_ids,omitempty"`
+	// LastID is the newest tweet ID emitted by a Watcher.
+	LastID    string    `json:"last_id,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Checkpointer stores positions of paginated streams so they can be resumed after a restart.
+// Load returns nil without error when there is no checkpoint for the operation and query.
+type Checkpointer interface {
+	Load(operation, query string) (*Checkpoint, error)
+	Save(checkpoint Checkpoint) error
+	Delete(operation, query string) error
+}
+
+func checkpointKey(operation, query string) string {
+	return operation + "\x00" + query
+}
+
+// MemoryCheckpointer keeps checkpoints in memory.
+type MemoryCheckpointer struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewMemoryCheckpointer creates an empty MemoryCheckpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{checkpoints: make(map[string]Checkpoint)}
+}
+
+func (c *MemoryCheckpointer) Load(operation, query string) (*Checkpoint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if checkpoint, ok := c.checkpoints[checkpointKey(operation, query)]; ok {
+		return &checkpoint, nil
+	}
+	return nil, nil
+}
+
+func (c *MemoryCheckpointer) Save(checkpoint Checkpoint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkpoints[checkpointKey(checkpoint.Operation, checkpoint.Query)] = checkpoint
+	return nil
+}
+
+func (c *MemoryCheckpointer) Delete(operation, query string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.checkpoints, checkpointKey(operation, query))
+	return nil
+}
+
+// FileCheckpointer keeps checkpoints in a JSON file.
+// The file is rewritten on every save, the content is synced to disk
+// before it replaces the old file, so a crash leaves either of them.
+type FileCheckpointer struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpointer creates a FileCheckpointer, the file is created on first save.
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{path: path}
+}
+
+func (c *FileCheckpointer) Load(operation, query string) (*Checkpoint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checkpoints, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Operation == operation && checkpoint.Query == query {
+			return &checkpoint, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *FileCheckpointer) Save(checkpoint Checkpoint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checkpoints, err := c.read()
+	if err != nil {
+		return err
+	}
+	for i := range checkpoints {
+		if checkpoints[i].Operation == checkpoint.Operation && checkpoints[i].Query == checkpoint.Query {
+			checkpoints[i] = checkpoint
+			return c.write(checkpoints)
+		}
+	}
+	return c.write(append(checkpoints, checkpoint))
+}
+
+func (c *FileCheckpointer) Delete(operation, query string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	checkpoints, err := c.read()
+	if err != nil {
+		return err
+	}
+	kept := checkpoints[:0]
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Operation != operation || checkpoint.Query != query {
+			kept = append(kept, checkpoint)
+		}
+	}
+	return c.write(kept)
+}
+
+func (c *FileCheckpointer) read() ([]Checkpoint, error) {
+	content, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var checkpoints []Checkpoint
+	if err := json.Unmarshal(content, &checkpoints); err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+func (c *FileCheckpointer) write(checkpoints []Checkpoint) error {
+	content, err := json.MarshalIndent(checkpoints, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	// the content must be on disk before the rename, or a crash can leave an empty file
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,63 @@
+package twitterscraper_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func assertCheckpointer(t *testing.T, checkpointer twitterscraper.Checkpointer) {
+	checkpoint, err := checkpointer.Load(twitterscraper.OperationFollowers, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint != nil {
+		t.Fatalf("Expected no checkpoint, got: %#v", checkpoint)
+	}
+
+	saved := twitterscraper.Checkpoint{
+		Operation: twitterscraper.OperationFollowers,
+		Query:     "x",
+		Cursor:    "cursor",
+		Offset:    3,
+		Count:     23,
+		SeenIDs:   []string{"1", "2"},
+	}
+	if err := checkpointer.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	other := saved
+	other.Query = "Support"
+	if err := checkpointer.Save(other); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint, err = checkpointer.Load(twitterscraper.OperationFollowers, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint == nil || !reflect.DeepEqual(*checkpoint, saved) {
+		t.Errorf("Expected checkpoint %#v, got: %#v", saved, checkpoint)
+	}
+
+	if err := checkpointer.Delete(twitterscraper.OperationFollowers, "x"); err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint, _ = checkpointer.Load(twitterscraper.OperationFollowers, "x"); checkpoint != nil {
+		t.Errorf("Expected deleted checkpoint, got: %#v", checkpoint)
+	}
+	if checkpoint, _ = checkpointer.Load(twitterscraper.OperationFollowers, "Support"); checkpoint == nil {
+		t.Error("Expected checkpoint for other query is kept")
+	}
+}
+
+func TestMemoryCheckpointer(t *testing.T) {
+	assertCheckpointer(t, twitterscraper.NewMemoryCheckpointer())
+}
+
+func TestFileCheckpointer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+	assertCheckpointer(t, twitterscraper.NewFileCheckpointer(path))
+}
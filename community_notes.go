@@ -0,0 +1,164 @@
+package twitterscraper
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// Rating statuses of community notes.
+const (
+	NoteRatedHelpful    = "CurrentlyRatedHelpful"
+	NoteRatedNotHelpful = "CurrentlyRatedNotHelpful"
+	NoteNeedsMoreRating = "NeedsMoreRatings"
+)
+
+type birdwatchText struct {
+	Text     string `json:"text"`
+	Entities []struct {
+		FromIndex int `json:"fromIndex"`
+		ToIndex   int `json:"toIndex"`
+		Ref       struct {
+			Type    string `json:"type"`
+			URL     string `json:"url"`
+			URLType string `json:"urlType"`
+		} `json:"ref"`
+	} `json:"entities"`
+}
+
+func (text *birdwatchText) parseEntities() []URLEntity {
+	var entities []URLEntity
+	for _, entity := range text.Entities {
+		if entity.Ref.URL == "" {
+			continue
+		}
+		entities = append(entities, URLEntity{
+			URL:         entity.Ref.URL,
+			ExpandedURL: entity.Ref.URL,
+			Indices:     [2]int{entity.FromIndex, entity.ToIndex},
+		})
+	}
+	return entities
+}
+
+type birdwatchPivot struct {
+	Title          string        `json:"title"`
+	ShortTitle     string        `json:"shorttitle"`
+	DestinationURL string        `json:"destinationUrl"`
+	Subtitle       birdwatchText `json:"subtitle"`
+	Note           struct {
+		RestID       string `json:"rest_id"`
+		RatingStatus string `json:"rating_status"`
+	} `json:"note"`
+}
+
+// parse returns the note shown under a tweet. The pivot usually has no rating
+// status, it is left empty then and can be got with GetCommunityNote.
+func (pivot *birdwatchPivot) parse(tweetID string) *CommunityNote {
+	if pivot.Note.RestID == "" && pivot.Subtitle.Text == "" {
+		return nil
+	}
+	return &CommunityNote{
+		ID:           pivot.Note.RestID,
+		TweetID:      tweetID,
+		Title:        pivot.Title,
+		Text:         pivot.Subtitle.Text,
+		Entities:     pivot.Subtitle.parseEntities(),
+		RatingStatus: pivot.Note.RatingStatus,
+		URL:          pivot.DestinationURL,
+	}
+}
+
+type birdwatchNote struct {
+	RestID string `json:"rest_id"`
+	DataV1 struct {
+		Summary        birdwatchText `json:"summary"`
+		Classification string        `json:"classification"`
+		MisleadingTags []string      `json:"misleading_tags"`
+	} `json:"data_v1"`
+	RatingStatus string   `json:"rating_status"`
+	HelpfulTags  []string `json:"helpful_tags"`
+	Language     string   `json:"language"`
+	CreatedAt    int64    `json:"created_at"`
+	TweetResults struct {
+		Result struct {
+			RestID string `json:"rest_id"`
+		} `json:"result"`
+	} `json:"tweet_results"`
+}
+
+func (note *birdwatchNote) parse() *CommunityNote {
+	communityNote := &CommunityNote{
+		ID:             note.RestID,
+		TweetID:        note.TweetResults.Result.RestID,
+		Text:           note.DataV1.Summary.Text,
+		Entities:       note.DataV1.Summary.parseEntities(),
+		RatingStatus:   note.RatingStatus,
+		Classification: note.DataV1.Classification,
+		MisleadingTags: note.DataV1.MisleadingTags,
+		HelpfulTags:    note.HelpfulTags,
+		Language:       note.Language,
+		URL:            "https://twitter.com/i/birdwatch/n/" + note.RestID,
+	}
+	if note.CreatedAt > 0 {
+		communityNote.CreatedAt = time.Unix(0, note.CreatedAt*int64(time.Millisecond)).UTC()
+	}
+	return communityNote
+}
+
+// GetCommunityNote returns a community note by its ID, including notes which are not shown under the tweet yet.
+func (s *Scraper) GetCommunityNote(noteID string) (*CommunityNote, error) {
+	if !s.isLogged {
+		return nil, errors.New("scraper is not logged in")
+	}
+
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/GO8BR2MM2WZB63cdOoC7lw/BirdwatchFetchOneNote")
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"note_id": noteID,
+	}
+	features := map[string]interface{}{
+		"responsive_web_birdwatch_media_notes_enabled":                            true,
+		"responsive_web_birdwatch_note_limit_enabled":                             true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"tweetypie_unmention_optimization_enabled":                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(features))
+	req.URL.RawQuery = query.Encode()
+
+	var response struct {
+		Data struct {
+			Note *birdwatchNote `json:"birdwatch_note_by_rest_id"`
+		} `json:"data"`
+	}
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return nil, err
+	}
+	if response.Data.Note == nil || response.Data.Note.RestID == "" {
+		return nil, errors.New("community note not found")
+	}
+	return response.Data.Note.parse(), nil
+}
@@ -0,0 +1,94 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const noteTweetJSON = `{
+	"__typename": "Tweet",
+	"rest_id": "100",
+	"core": {"user_results": {"result": {"rest_id": "1", "legacy": {"screen_name": "x", "name": "X"}}}},
+	"birdwatch_pivot": {
+		"destinationUrl": "https://twitter.com/i/birdwatch/n/200",
+		"note": {"rest_id": "200"},
+		"shorttitle": "Readers added context",
+		"subtitle": {
+			"text": "The photo is from 2019. example.com/source",
+			"entities": [
+				{"fromIndex": 24, "toIndex": 42, "ref": {"type": "TimelineUrl", "url": "https://t.co/abc", "urlType": "ExternalUrl"}},
+				{"fromIndex": 0, "toIndex": 3, "ref": {"type": "TimelineRichTextUser"}}
+			]
+		},
+		"title": "Readers added context they thought people might want to know",
+		"visualStyle": "Default"
+	},
+	"legacy": {"id_str": "100", "full_text": "photo", "user_id_str": "1", "conversation_id_str": "100"}
+}`
+
+func TestBirdwatchPivotParse(t *testing.T) {
+	var tweetResult result
+	if err := json.Unmarshal([]byte(noteTweetJSON), &tweetResult); err != nil {
+		t.Fatal(err)
+	}
+	expected := &CommunityNote{
+		ID:       "200",
+		TweetID:  "100",
+		Title:    "Readers added context they thought people might want to know",
+		Text:     "The photo is from 2019. example.com/source",
+		Entities: []URLEntity{{URL: "https://t.co/abc", ExpandedURL: "https://t.co/abc", Indices: [2]int{24, 42}}},
+		URL:      "https://twitter.com/i/birdwatch/n/200",
+	}
+	if diff := cmp.Diff(expected, tweetResult.parse().CommunityNote); diff != "" {
+		t.Error("Parsed community note does not match", diff)
+	}
+
+	var rated birdwatchPivot
+	if err := json.Unmarshal([]byte(`{"note": {"rest_id": "200", "rating_status": "CurrentlyRatedHelpful"}}`), &rated); err != nil {
+		t.Fatal(err)
+	}
+	if note := rated.parse("100"); note == nil || note.RatingStatus != NoteRatedHelpful {
+		t.Errorf("Expected rating status from the payload, got %#v", note)
+	}
+	if note := (&birdwatchPivot{}).parse("100"); note != nil {
+		t.Errorf("Expected no note for an empty pivot, got %#v", note)
+	}
+}
+
+func TestBirdwatchNoteParse(t *testing.T) {
+	var note birdwatchNote
+	err := json.Unmarshal([]byte(`{
+		"rest_id": "200",
+		"data_v1": {
+			"summary": {"text": "Not a real photo."},
+			"classification": "MisinformedOrPotentiallyMisleading",
+			"misleading_tags": ["ManipulatedMedia"]
+		},
+		"rating_status": "NeedsMoreRatings",
+		"helpful_tags": ["GoodSources"],
+		"language": "en",
+		"created_at": 1700000000000,
+		"tweet_results": {"result": {"rest_id": "100"}}
+	}`), &note)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &CommunityNote{
+		ID:             "200",
+		TweetID:        "100",
+		Text:           "Not a real photo.",
+		RatingStatus:   NoteNeedsMoreRating,
+		Classification: "MisinformedOrPotentiallyMisleading",
+		MisleadingTags: []string{"ManipulatedMedia"},
+		HelpfulTags:    []string{"GoodSources"},
+		Language:       "en",
+		CreatedAt:      time.Unix(1700000000, 0).UTC(),
+		URL:            "https://twitter.com/i/birdwatch/n/200",
+	}
+	if diff := cmp.Diff(expected, note.parse()); diff != "" {
+		t.Error("Parsed community note does not match", diff)
+	}
+}
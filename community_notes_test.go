@@ -0,0 +1,14 @@
+package twitterscraper_test
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestGetCommunityNoteRequiresLogin(t *testing.T) {
+	scraper := twitterscraper.New()
+	if _, err := scraper.GetCommunityNote("1"); err == nil {
+		t.Error("Expected error for scraper which is not logged in")
+	}
+}
@@ -0,0 +1,142 @@
+package twitterscraper
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+type editControlInitial struct {
+	EditTweetIDs       []string `json:"edit_tweet_ids"`
+	EditableUntilMsecs string   `json:"editable_until_msecs"`
+	IsEditEligible     bool     `json:"is_edit_eligible"`
+	EditsRemaining     string   `json:"edits_remaining"`
+}
+
+// editControl is set on the first version of a tweet directly and
+// on later versions with the initial tweet ID and its edit control.
+type editControl struct {
+	editControlInitial
+	InitialTweetID     string              `json:"initial_tweet_id"`
+	EditControlInitial *editControlInitial `json:"edit_control_initial"`
+}
+
+func (control *editControl) parse(tweetID string) *EditControl {
+	initial := &control.editControlInitial
+	initialTweetID := control.InitialTweetID
+	if control.EditControlInitial != nil {
+		initial = control.EditControlInitial
+	}
+	if initialTweetID == "" {
+		initialTweetID = tweetID
+	}
+	return initial.parse(initialTweetID)
+}
+
+func (initial *editControlInitial) parse(initialTweetID string) *EditControl {
+	ec := &EditControl{
+		InitialTweetID: initialTweetID,
+		EditTweetIDs:   initial.EditTweetIDs,
+		IsEditEligible: initial.IsEditEligible,
+	}
+	if ms, err := strconv.ParseInt(initial.EditableUntilMsecs, 10, 64); err == nil {
+		ec.EditableUntil = time.Unix(0, ms*int64(time.Millisecond)).UTC()
+	}
+	ec.EditsRemaining, _ = strconv.Atoi(initial.EditsRemaining)
+	return ec
+}
+
+type editControlV1Initial struct {
+	EditTweetIDs       []string `json:"editTweetIds"`
+	EditableUntilMsecs string   `json:"editableUntilMsecs"`
+	IsEditEligible     bool     `json:"isEditEligible"`
+	EditsRemaining     string   `json:"editsRemaining"`
+}
+
+// editControlV1 is the editControl extension of v1 timelines.
+type editControlV1 struct {
+	Initial *editControlV1Initial `json:"initial"`
+	Edit    *struct {
+		InitialTweetID     string               `json:"initialTweetId"`
+		EditControlInitial editControlV1Initial `json:"editControlInitial"`
+	} `json:"edit"`
+}
+
+func (control *editControlV1) parse(tweetID string) *EditControl {
+	var initial editControlV1Initial
+	initialTweetID := tweetID
+	if control.Initial != nil {
+		initial = *control.Initial
+	} else if control.Edit != nil {
+		initial = control.Edit.EditControlInitial
+		initialTweetID = control.Edit.InitialTweetID
+	} else {
+		return nil
+	}
+	return (&editControlInitial{
+		EditTweetIDs:       initial.EditTweetIDs,
+		EditableUntilMsecs: initial.EditableUntilMsecs,
+		IsEditEligible:     initial.IsEditEligible,
+		EditsRemaining:     initial.EditsRemaining,
+	}).parse(initialTweetID)
+}
+
+type previousCounts struct {
+	BookmarkCount int `json:"bookmark_count"`
+	FavoriteCount int `json:"favorite_count"`
+	QuoteCount    int `json:"quote_count"`
+	ReplyCount    int `json:"reply_count"`
+	RetweetCount  int `json:"retweet_count"`
+}
+
+func (counts *previousCounts) parse() *TweetCounts {
+	return &TweetCounts{
+		Bookmarks: counts.BookmarkCount,
+		Likes:     counts.FavoriteCount,
+		Quotes:    counts.QuoteCount,
+		Replies:   counts.ReplyCount,
+		Retweets:  counts.RetweetCount,
+	}
+}
+
+// IsEdited reports whether the tweet has more than one version.
+func (ec *EditControl) IsEdited() bool {
+	return ec != nil && len(ec.EditTweetIDs) > 1
+}
+
+// GetTweetEditHistory returns every version of a tweet from the first to the latest.
+// A tweet which was never edited is returned as the only version.
+func (s *Scraper) GetTweetEditHistory(id string) ([]*Tweet, error) {
+	tweet, err := s.GetTweet(id)
+	if err != nil {
+		return nil, err
+	}
+	return editHistory(tweet, s.GetTweet)
+}
+
+// editHistory returns versions of tweet ordered by ID, the other versions are fetched with getTweet.
+func editHistory(tweet *Tweet, getTweet func(id string) (*Tweet, error)) ([]*Tweet, error) {
+	if !tweet.EditControl.IsEdited() {
+		return []*Tweet{tweet}, nil
+	}
+
+	ids := append([]string(nil), tweet.EditControl.EditTweetIDs...)
+	sort.Slice(ids, func(i, j int) bool {
+		return snowflake.Compare(ids[i], ids[j]) < 0
+	})
+	versions := make([]*Tweet, 0, len(ids))
+	for _, versionID := range ids {
+		if versionID == tweet.ID {
+			versions = append(versions, tweet)
+			continue
+		}
+		version, err := getTweet(versionID)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
@@ -0,0 +1,90 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// editedTweetJSON is the second of three versions, its IDs cross a power of ten
+// so that they sort differently as strings and as numbers.
+const editedTweetJSON = `{
+	"__typename": "Tweet",
+	"rest_id": "1000000000000000001",
+	"core": {"user_results": {"result": {"rest_id": "1", "legacy": {"screen_name": "x", "name": "X"}}}},
+	"edit_control": {
+		"initial_tweet_id": "999999999999999999",
+		"edit_control_initial": {
+			"edit_tweet_ids": ["1000000000000000002", "999999999999999999", "1000000000000000001"],
+			"editable_until_msecs": "1700000000000",
+			"is_edit_eligible": true,
+			"edits_remaining": "2"
+		}
+	},
+	"previous_counts": {"bookmark_count": 1, "favorite_count": 2, "quote_count": 3, "reply_count": 4, "retweet_count": 5},
+	"legacy": {"id_str": "1000000000000000001", "full_text": "second", "user_id_str": "1", "conversation_id_str": "999999999999999999"}
+}`
+
+func TestEditHistory(t *testing.T) {
+	var edited result
+	if err := json.Unmarshal([]byte(editedTweetJSON), &edited); err != nil {
+		t.Fatal(err)
+	}
+	tweet := edited.parse()
+
+	expectedControl := &EditControl{
+		InitialTweetID: "999999999999999999",
+		EditTweetIDs:   []string{"1000000000000000002", "999999999999999999", "1000000000000000001"},
+		EditableUntil:  time.Unix(1700000000, 0).UTC(),
+		IsEditEligible: true,
+		EditsRemaining: 2,
+	}
+	if !reflect.DeepEqual(tweet.EditControl, expectedControl) {
+		t.Errorf("Expected edit control %#v, got %#v", expectedControl, tweet.EditControl)
+	}
+	if !tweet.EditControl.IsEdited() {
+		t.Error("Expected tweet is edited")
+	}
+	expectedCounts := &TweetCounts{Bookmarks: 1, Likes: 2, Quotes: 3, Replies: 4, Retweets: 5}
+	if !reflect.DeepEqual(tweet.PreviousCounts, expectedCounts) {
+		t.Errorf("Expected previous counts %#v, got %#v", expectedCounts, tweet.PreviousCounts)
+	}
+
+	var fetched []string
+	getTweet := func(id string) (*Tweet, error) {
+		fetched = append(fetched, id)
+		return &Tweet{ID: id}, nil
+	}
+	versions, err := editHistory(tweet, getTweet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, version := range versions {
+		ids = append(ids, version.ID)
+	}
+	if expected := []string{"999999999999999999", "1000000000000000001", "1000000000000000002"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected versions %v, got %v", expected, ids)
+	}
+	if versions[1] != tweet {
+		t.Error("Expected the given tweet is reused as its version")
+	}
+	if expected := []string{"999999999999999999", "1000000000000000002"}; !reflect.DeepEqual(fetched, expected) {
+		t.Errorf("Expected only other versions are fetched, got %v", fetched)
+	}
+	if !reflect.DeepEqual(tweet.EditControl.EditTweetIDs, expectedControl.EditTweetIDs) {
+		t.Errorf("Expected edit tweet IDs are not reordered, got %v", tweet.EditControl.EditTweetIDs)
+	}
+
+	fetchErr := errors.New("not found")
+	if _, err := editHistory(tweet, func(id string) (*Tweet, error) { return nil, fetchErr }); err != fetchErr {
+		t.Errorf("Expected error of a missing version, got %v", err)
+	}
+
+	single := &Tweet{ID: "1"}
+	if versions, err := editHistory(single, nil); err != nil || len(versions) != 1 || versions[0] != single {
+		t.Errorf("Expected tweet which was never edited is the only version, got %v, %v", versions, err)
+	}
+}
@@ -0,0 +1,117 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// FanOutTarget is a user whose tweets are fetched by FanOut.
+// Either Username or UserID must be set.
+type FanOutTarget struct {
+	Username string
+	UserID   string
+	// MaxTweetsNbr must be positive, a target without it gets an error.
+	MaxTweetsNbr int
+	Options      TimelineOptions
+}
+
+// FanOutResult is a tweet or an error tagged with the user it came from.
+type FanOutResult struct {
+	TweetResult
+	Username string
+	UserID   string
+}
+
+// FanOut fetches tweets of many users concurrently and merges them into one channel.
+// Usernames are resolved to IDs first, then up to workers timelines are fetched at once.
+// Requests still follow the delay set by WithDelay. An error of one user is sent
+// tagged with that user and doesn't stop the others.
+func (s *Scraper) FanOut(ctx context.Context, targets []FanOutTarget, workers int) <-chan *FanOutResult {
+	return s.fanOut(ctx, targets, workers, s.GetUserIDByScreenName, s.fetchTweetsByUserID)
+}
+
+func (s *Scraper) fanOut(ctx context.Context, targets []FanOutTarget, workers int, resolve func(username string) (string, error), fetchFunc fetchTweetFunc) <-chan *FanOutResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	channel := make(chan *FanOutResult)
+	send := func(result *FanOutResult) bool {
+		select {
+		case channel <- result:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(channel)
+
+		targets := append([]FanOutTarget(nil), targets...)
+		errs := make([]error, len(targets))
+
+		runWorkers(ctx, len(targets), workers, func(i int) {
+			if targets[i].MaxTweetsNbr < 1 {
+				errs[i] = errors.New("max tweets number must be positive")
+			} else if targets[i].UserID == "" {
+				targets[i].UserID, errs[i] = resolve(targets[i].Username)
+			}
+		})
+
+		runWorkers(ctx, len(targets), workers, func(i int) {
+			target := targets[i]
+			if errs[i] != nil {
+				send(&FanOutResult{TweetResult: TweetResult{Error: errs[i]}, Username: target.Username, UserID: target.UserID})
+				return
+			}
+
+			it := s.newTweetIterator(ctx, OperationTweetsByUserID, target.UserID, target.MaxTweetsNbr, fetchFunc).WithOptions(target.Options)
+			defer it.Close()
+			for it.Next() {
+				if !send(&FanOutResult{TweetResult: TweetResult{Tweet: *it.Tweet()}, Username: target.Username, UserID: target.UserID}) {
+					return
+				}
+			}
+			if err := it.Err(); err != nil {
+				send(&FanOutResult{TweetResult: TweetResult{Error: err}, Username: target.Username, UserID: target.UserID})
+			}
+		})
+	}()
+
+	return channel
+}
+
+// fetchTweetsByUserID picks the same endpoint as FetchTweets for already resolved user ID.
+func (s *Scraper) fetchTweetsByUserID(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if s.isOpenAccount {
+		return s.FetchTweetsByUserIDLegacy(userID, maxTweetsNbr, cursor)
+	}
+	return s.FetchTweetsByUserID(userID, maxTweetsNbr, cursor)
+}
+
+// runWorkers calls work for every index from 0 to n-1 using up to workers goroutines.
+func runWorkers(ctx context.Context, n int, workers int, work func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				work(i)
+			}
+		}()
+	}
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
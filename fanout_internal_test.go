@@ -0,0 +1,121 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeUserTimelines serves pages of 2 tweets for user IDs, "id-fail" fails and
+// "id-endless" timelines never end. It records the most timelines fetched at once.
+type fakeUserTimelines struct {
+	pages   int
+	active  int32
+	busiest int32
+	mu      sync.Mutex
+	fetches map[string]int
+}
+
+func (f *fakeUserTimelines) fetch(userID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	active := atomic.AddInt32(&f.active, 1)
+	defer atomic.AddInt32(&f.active, -1)
+	for {
+		busiest := atomic.LoadInt32(&f.busiest)
+		if active <= busiest || atomic.CompareAndSwapInt32(&f.busiest, busiest, active) {
+			break
+		}
+	}
+	f.mu.Lock()
+	f.fetches[userID]++
+	f.mu.Unlock()
+	// let other workers overlap
+	time.Sleep(time.Millisecond)
+
+	if userID == "id-fail" {
+		return nil, "", errors.New("timeline unavailable")
+	}
+	page, _ := strconv.Atoi(cursor)
+	tweets := []*Tweet{
+		{ID: userID + "-" + strconv.Itoa(page*2), EntryType: EntryOrganic},
+		{ID: userID + "-" + strconv.Itoa(page*2+1), EntryType: EntryOrganic},
+	}
+	next := strconv.Itoa(page + 1)
+	if page+1 >= f.pages && !strings.HasPrefix(userID, "id-endless") {
+		next = ""
+	}
+	return tweets, next, nil
+}
+
+func fakeResolve(username string) (string, error) {
+	if username == "missing" {
+		return "", errors.New("user not found")
+	}
+	return "id-" + username, nil
+}
+
+func TestFanOutWorkers(t *testing.T) {
+	timelines := &fakeUserTimelines{pages: 3, fetches: make(map[string]int)}
+	targets := []FanOutTarget{
+		{Username: "a", MaxTweetsNbr: 5},
+		{UserID: "id-b", MaxTweetsNbr: 100},
+		{Username: "missing", MaxTweetsNbr: 5},
+		{Username: "fail", MaxTweetsNbr: 5},
+		{Username: "c", MaxTweetsNbr: 3},
+		{Username: "unlimited"},
+	}
+	counts := make(map[string]int)
+	failed := make(map[string]error)
+	for result := range New().fanOut(context.Background(), targets, 2, fakeResolve, timelines.fetch) {
+		if result.Error != nil {
+			failed[result.Username+result.UserID] = result.Error
+			continue
+		}
+		if result.UserID == "" {
+			t.Errorf("Expected results tagged with user ID, got tweet %s", result.ID)
+		}
+		counts[result.UserID]++
+	}
+
+	if expected := map[string]int{"id-a": 5, "id-b": 6, "id-c": 3}; !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected tweet counts %v, got %v", expected, counts)
+	}
+	// errors are tagged and don't stop the other users
+	for _, key := range []string{"missing", "failid-fail", "unlimited"} {
+		if failed[key] == nil {
+			t.Errorf("Expected error for %s, got %v", key, failed)
+		}
+	}
+	if len(failed) != 3 {
+		t.Errorf("Expected 3 errors, got %v", failed)
+	}
+	if timelines.fetches["id-unlimited"] != 0 {
+		t.Error("Expected no requests for a target without limit")
+	}
+	if busiest := atomic.LoadInt32(&timelines.busiest); busiest > 2 {
+		t.Errorf("Expected at most 2 timelines fetched at once, got %d", busiest)
+	}
+}
+
+func TestFanOutCancel(t *testing.T) {
+	timelines := &fakeUserTimelines{fetches: make(map[string]int)}
+	targets := []FanOutTarget{
+		{UserID: "id-endless", MaxTweetsNbr: 1000000},
+		{UserID: "id-endless2", MaxTweetsNbr: 1000000},
+		{UserID: "id-endless3", MaxTweetsNbr: 1000000},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	channel := New().fanOut(ctx, targets, 2, fakeResolve, timelines.fetch)
+	if result := <-channel; result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	cancel()
+	// the workers stop and the channel is closed
+	for range channel {
+	}
+}
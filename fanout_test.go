@@ -0,0 +1,36 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestFanOut(t *testing.T) {
+	targets := []twitterscraper.FanOutTarget{
+		{Username: "x", MaxTweetsNbr: 20},
+		{UserID: "17874544", MaxTweetsNbr: 20},
+		{Username: "sample3123131", MaxTweetsNbr: 20},
+	}
+	counts := make(map[string]int)
+	failed := make(map[string]bool)
+	for result := range testScraper.FanOut(context.Background(), targets, 2) {
+		if result.Error != nil {
+			failed[result.Username] = true
+			continue
+		}
+		if result.UserID == "" {
+			t.Error("Expected result UserID is empty")
+		}
+		counts[result.UserID]++
+	}
+	if !failed["sample3123131"] {
+		t.Error("Expected error for not existing user")
+	}
+	for _, userID := range []string{"783214", "17874544"} {
+		if counts[userID] != 20 {
+			t.Errorf("Expected tweets count=%v for user %s, got: %v", 20, userID, counts[userID])
+		}
+	}
+}
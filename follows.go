@@ -1,10 +1,21 @@
 package twitterscraper
 
 import (
+	"context"
 	"net/url"
 	"strings"
 )
 
+// IterFollowing returns iterator over following profiles for a given user.
+func (s *Scraper) IterFollowing(ctx context.Context, user string, maxUsersNbr int) *ProfileIterator {
+	return s.newProfileIterator(ctx, OperationFollowing, user, maxUsersNbr, s.FetchFollowing)
+}
+
+// IterFollowers returns iterator over followers profiles for a given user.
+func (s *Scraper) IterFollowers(ctx context.Context, user string, maxUsersNbr int) *ProfileIterator {
+	return s.newProfileIterator(ctx, OperationFollowers, user, maxUsersNbr, s.FetchFollowers)
+}
+
 // FetchFollowing gets following profiles list for a given user, via the Twitter frontend GraphQL API.
 func (s *Scraper) FetchFollowing(user string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	userID, err := s.GetUserIDByScreenName(user)
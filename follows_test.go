@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"testing"
 )
 
@@ -29,3 +30,25 @@ func TestFetchFollowers(t *testing.T) {
 		t.Error("error FetchFollowing() No users found")
 	}
 }
+
+func TestIterFollowers(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	maxUsersNbr := 40
+	it := testScraper.IterFollowers(context.Background(), "Support", maxUsersNbr)
+	defer it.Close()
+	for it.Next() {
+		count++
+		if it.Profile().Username == "" {
+			t.Error("Expected profile Username is empty")
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+	if count != maxUsersNbr {
+		t.Errorf("Expected profiles count=%v, got: %v", maxUsersNbr, count)
+	}
+}
@@ -0,0 +1,750 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// TweetIterator pages through a tweet timeline on demand.
+// Unlike the channel helpers it runs no goroutine, so it can be abandoned at any time.
+//
+//	it := scraper.IterTweets(ctx, "x", 100)
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Tweet().Text)
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error, it.Cursor() can be used to resume later
+//	}
+type TweetIterator struct {
+	ctx          context.Context
+	operation    string
+	query        string
+	maxTweetsNbr int
+	fetchFunc    fetchTweetFunc
+	options      TimelineOptions
+	params       url.Values
+	seen         *seenIDs
+	checkpointer Checkpointer
+	started      bool
+	cursor       string
+	pageCursor   string
+	offset       int
+	skip         int
+	fetched      bool
+	page         []*Tweet
+	tweet        *Tweet
+	tweetsNbr    int
+	resumedNbr   int
+	err          error
+	done         bool
+}
+
+func (s *Scraper) newTweetIterator(ctx context.Context, operation string, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) *TweetIterator {
+	return &TweetIterator{
+		ctx:          ctx,
+		operation:    operation,
+		query:        query,
+		maxTweetsNbr: maxTweetsNbr,
+		fetchFunc:    fetchFunc,
+	}
+}
+
+// WithCursor starts the iteration from a cursor previously returned by Cursor.
+// It must be called before the first Next.
+func (it *TweetIterator) WithCursor(cursor string) *TweetIterator {
+	it.cursor = cursor
+	it.started = true
+	return it
+}
+
+// WithOptions sets bounds and filters of the timeline. It must be called before the first Next.
+func (it *TweetIterator) WithOptions(options TimelineOptions) *TweetIterator {
+	it.options = options
+	return it
+}
+
+// WithCheckpointer resumes the iteration from the checkpoint saved in checkpointer
+// and keeps it updated every CheckpointInterval items, at the end of every page and on Close.
+// After a crash up to CheckpointInterval-1 items are emitted again. It must be called before the first Next.
+// The limit applies to the items emitted by this iterator, not counting the ones
+// emitted before the resume. A checkpoint of a finished stream is ignored, so the
+// iteration starts from scratch.
+func (it *TweetIterator) WithCheckpointer(checkpointer Checkpointer) *TweetIterator {
+	it.checkpointer = checkpointer
+	return it
+}
+
+// Next advances to the next tweet, fetching a new page when needed.
+// It returns false when the timeline is exhausted, the limit is reached or an error occurred.
+func (it *TweetIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		if err := it.resume(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.tweetsNbr >= it.maxTweetsNbr {
+		it.stop()
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		it.save(false)
+		return false
+	}
+
+	for {
+		if len(it.page) == 0 && !it.fetchPage() {
+			return false
+		}
+
+		tweet := it.page[0]
+		it.page = it.page[1:]
+		it.offset++
+
+		if it.options.OrganicOnly && tweet.EntryType != EntryOrganic {
+			continue
+		}
+		switch it.options.bound(tweet) {
+		case boundSkip:
+			continue
+		case boundStop:
+			it.done = true
+			it.save(true)
+			return false
+		}
+		if !it.options.AllowDuplicates {
+			if it.seen == nil {
+				it.seen = newSeenIDs(it.options.dedupeWindow())
+			}
+			if !it.seen.add(tweet.ID) {
+				continue
+			}
+		}
+
+		it.tweet = tweet
+		it.tweetsNbr++
+		// a crash mid-page emits again only the tweets since the last save
+		if it.tweetsNbr%CheckpointInterval != 0 {
+			return true
+		}
+		return it.save(false)
+	}
+}
+
+// fetchPage fetches pages until a non-empty one is found.
+// It returns false when the timeline is over or an error occurred.
+func (it *TweetIterator) fetchPage() bool {
+	for len(it.page) == 0 {
+		if it.fetched {
+			// the whole page was emitted, resume from the next one
+			it.pageCursor = it.cursor
+			it.offset = 0
+			if it.cursor == "" {
+				it.done = true
+				it.save(true)
+				return false
+			}
+			if !it.save(false) {
+				return false
+			}
+			if err := it.ctx.Err(); err != nil {
+				it.err = err
+				return false
+			}
+		}
+
+		tweets, next, err := it.fetchFunc(it.query, it.maxTweetsNbr, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(tweets) == 0 || (it.fetched && next == it.cursor) {
+			it.done = true
+			it.save(true)
+			return false
+		}
+
+		it.fetched = true
+		it.page = tweets
+		it.pageCursor = it.cursor
+		it.cursor = next
+		if it.skip > 0 {
+			skip := it.skip
+			if skip > len(it.page) {
+				skip = len(it.page)
+			}
+			it.page = it.page[skip:]
+			it.offset = skip
+			it.skip = 0
+		}
+	}
+	return true
+}
+
+func (it *TweetIterator) resume() error {
+	if it.checkpointer == nil {
+		return nil
+	}
+	checkpoint, err := it.checkpointer.Load(it.operation, it.checkpointQuery())
+	if err != nil || checkpoint == nil || checkpoint.Done {
+		return err
+	}
+	it.cursor = checkpoint.Cursor
+	it.pageCursor = checkpoint.Cursor
+	it.skip = checkpoint.Offset
+	it.resumedNbr = checkpoint.Count
+	if !it.options.AllowDuplicates && len(checkpoint.SeenIDs) > 0 {
+		it.seen = newSeenIDs(it.options.dedupeWindow())
+		for _, id := range checkpoint.SeenIDs {
+			it.seen.add(id)
+		}
+	}
+	return nil
+}
+
+// checkpointQuery keys checkpoints by options and fetch params too, so the same
+// query with different bounds or search product doesn't resume from a foreign position.
+func (it *TweetIterator) checkpointQuery() string {
+	values := it.options.values()
+	for key, value := range it.params {
+		values[key] = value
+	}
+	if key := values.Encode(); key != "" {
+		return it.query + "?" + key
+	}
+	return it.query
+}
+
+// save stores the current position, false is returned if the checkpointer failed.
+func (it *TweetIterator) save(done bool) bool {
+	if it.checkpointer == nil {
+		return true
+	}
+	checkpoint := Checkpoint{
+		Operation: it.operation,
+		Query:     it.checkpointQuery(),
+		Cursor:    it.pageCursor,
+		Offset:    it.offset,
+		Count:     it.resumedNbr + it.tweetsNbr,
+		Done:      done,
+		UpdatedAt: time.Now(),
+	}
+	if it.seen != nil && !done {
+		checkpoint.SeenIDs = it.seen.list()
+	}
+	err := it.checkpointer.Save(checkpoint)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *TweetIterator) stop() {
+	if it.started && !it.done && it.err == nil {
+		it.save(false)
+	}
+	it.done = true
+	it.page = nil
+}
+
+// Tweet returns the current tweet.
+func (it *TweetIterator) Tweet() *Tweet {
+	return it.tweet
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *TweetIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor of the page following the current one.
+// Tweets left on the current page are not covered by it.
+func (it *TweetIterator) Cursor() string {
+	return it.cursor
+}
+
+// Close stops the iteration, no more pages are fetched after it.
+// The position is saved if a checkpointer is used.
+func (it *TweetIterator) Close() {
+	it.stop()
+}
+
+// Chan returns channel with the remaining tweets, like the Get methods do.
+// It ends with an error result if the iteration fails or the context is cancelled.
+// After the context is cancelled no more tweets are sent, drain the channel to
+// receive the cancellation error and let the goroutine filling it exit.
+func (it *TweetIterator) Chan() <-chan *TweetResult {
+	channel := make(chan *TweetResult)
+	go func() {
+		defer close(channel)
+		defer it.Close()
+		for it.Next() {
+			select {
+			case channel <- &TweetResult{Tweet: *it.Tweet()}:
+			case <-it.ctx.Done():
+				it.err = it.ctx.Err()
+			}
+			if it.err != nil {
+				break
+			}
+		}
+		if err := it.Err(); err != nil {
+			channel <- &TweetResult{Error: err}
+		}
+	}()
+	return channel
+}
+
+// ProfileIterator pages through a list of profiles on demand.
+// It works the same way as TweetIterator.
+type ProfileIterator struct {
+	ctx            context.Context
+	operation      string
+	query          string
+	maxProfilesNbr int
+	fetchFunc      fetchProfileFunc
+	checkpointer   Checkpointer
+	started        bool
+	cursor         string
+	pageCursor     string
+	offset         int
+	skip           int
+	fetched        bool
+	page           []*Profile
+	profile        *Profile
+	profilesNbr    int
+	resumedNbr     int
+	err            error
+	done           bool
+}
+
+func (s *Scraper) newProfileIterator(ctx context.Context, operation string, query string, maxProfilesNbr int, fetchFunc fetchProfileFunc) *ProfileIterator {
+	return &ProfileIterator{
+		ctx:            ctx,
+		operation:      operation,
+		query:          query,
+		maxProfilesNbr: maxProfilesNbr,
+		fetchFunc:      fetchFunc,
+	}
+}
+
+// WithCursor starts the iteration from a cursor previously returned by Cursor.
+// It must be called before the first Next.
+func (it *ProfileIterator) WithCursor(cursor string) *ProfileIterator {
+	it.cursor = cursor
+	it.started = true
+	return it
+}
+
+// WithCheckpointer resumes the iteration from the checkpoint saved in checkpointer
+// and keeps it updated every CheckpointInterval items, at the end of every page and on Close.
+// After a crash up to CheckpointInterval-1 items are emitted again. It must be called before the first Next.
+// The limit applies to the items emitted by this iterator, not counting the ones
+// emitted before the resume. A checkpoint of a finished stream is ignored, so the
+// iteration starts from scratch.
+func (it *ProfileIterator) WithCheckpointer(checkpointer Checkpointer) *ProfileIterator {
+	it.checkpointer = checkpointer
+	return it
+}
+
+// Next advances to the next profile, fetching a new page when needed.
+// It returns false when the list is exhausted, the limit is reached or an error occurred.
+func (it *ProfileIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		if err := it.resume(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.profilesNbr >= it.maxProfilesNbr {
+		it.stop()
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		it.save(false)
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if it.fetched {
+			// the whole page was emitted, resume from the next one
+			it.pageCursor = it.cursor
+			it.offset = 0
+			if it.cursor == "" {
+				it.done = true
+				it.save(true)
+				return false
+			}
+			if !it.save(false) {
+				return false
+			}
+			if err := it.ctx.Err(); err != nil {
+				it.err = err
+				return false
+			}
+		}
+
+		profiles, next, err := it.fetchFunc(it.query, it.maxProfilesNbr, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(profiles) == 0 || (it.fetched && next == it.cursor) {
+			it.done = true
+			it.save(true)
+			return false
+		}
+
+		it.fetched = true
+		it.page = profiles
+		it.pageCursor = it.cursor
+		it.cursor = next
+		if it.skip > 0 {
+			skip := it.skip
+			if skip > len(it.page) {
+				skip = len(it.page)
+			}
+			it.page = it.page[skip:]
+			it.offset = skip
+			it.skip = 0
+		}
+	}
+
+	it.profile = it.page[0]
+	it.page = it.page[1:]
+	it.offset++
+	it.profilesNbr++
+	// a crash mid-page emits again only the profiles since the last save
+	if it.profilesNbr%CheckpointInterval != 0 {
+		return true
+	}
+	return it.save(false)
+}
+
+func (it *ProfileIterator) resume() error {
+	if it.checkpointer == nil {
+		return nil
+	}
+	checkpoint, err := it.checkpointer.Load(it.operation, it.query)
+	if err != nil || checkpoint == nil || checkpoint.Done {
+		return err
+	}
+	it.cursor = checkpoint.Cursor
+	it.pageCursor = checkpoint.Cursor
+	it.skip = checkpoint.Offset
+	it.resumedNbr = checkpoint.Count
+	return nil
+}
+
+// save stores the current position, false is returned if the checkpointer failed.
+func (it *ProfileIterator) save(done bool) bool {
+	if it.checkpointer == nil {
+		return true
+	}
+	err := it.checkpointer.Save(Checkpoint{
+		Operation: it.operation,
+		Query:     it.query,
+		Cursor:    it.pageCursor,
+		Offset:    it.offset,
+		Count:     it.resumedNbr + it.profilesNbr,
+		Done:      done,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *ProfileIterator) stop() {
+	if it.started && !it.done && it.err == nil {
+		it.save(false)
+	}
+	it.done = true
+	it.page = nil
+}
+
+// Profile returns the current profile.
+func (it *ProfileIterator) Profile() *Profile {
+	return it.profile
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ProfileIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor of the page following the current one.
+// Profiles left on the current page are not covered by it.
+func (it *ProfileIterator) Cursor() string {
+	return it.cursor
+}
+
+// Close stops the iteration, no more pages are fetched after it.
+// The position is saved if a checkpointer is used.
+func (it *ProfileIterator) Close() {
+	it.stop()
+}
+
+// Chan returns channel with the remaining profiles, like the Get methods do.
+// It ends with an error result if the iteration fails or the context is cancelled.
+// After the context is cancelled no more profiles are sent, drain the channel to
+// receive the cancellation error and let the goroutine filling it exit.
+func (it *ProfileIterator) Chan() <-chan *ProfileResult {
+	channel := make(chan *ProfileResult)
+	go func() {
+		defer close(channel)
+		defer it.Close()
+		for it.Next() {
+			select {
+			case channel <- &ProfileResult{Profile: *it.Profile()}:
+			case <-it.ctx.Done():
+				it.err = it.ctx.Err()
+			}
+			if it.err != nil {
+				break
+			}
+		}
+		if err := it.Err(); err != nil {
+			channel <- &ProfileResult{Error: err}
+		}
+	}()
+	return channel
+}
+
+// ListIterator pages through a list of lists on demand.
+// It works the same way as TweetIterator.
+type ListIterator struct {
+	ctx          context.Context
+	operation    string
+	query        string
+	maxListsNbr  int
+	fetchFunc    fetchListFunc
+	checkpointer Checkpointer
+	started      bool
+	cursor       string
+	pageCursor   string
+	offset       int
+	skip         int
+	fetched      bool
+	page         []*List
+	list         *List
+	listsNbr     int
+	resumedNbr   int
+	err          error
+	done         bool
+}
+
+func (s *Scraper) newListIterator(ctx context.Context, operation string, query string, maxListsNbr int, fetchFunc fetchListFunc) *ListIterator {
+	return &ListIterator{
+		ctx:         ctx,
+		operation:   operation,
+		query:       query,
+		maxListsNbr: maxListsNbr,
+		fetchFunc:   fetchFunc,
+	}
+}
+
+// WithCursor starts the iteration from a cursor previously returned by Cursor.
+// It must be called before the first Next.
+func (it *ListIterator) WithCursor(cursor string) *ListIterator {
+	it.cursor = cursor
+	it.started = true
+	return it
+}
+
+// WithCheckpointer resumes the iteration from the checkpoint saved in checkpointer
+// and keeps it updated every CheckpointInterval items, at the end of every page and on Close.
+// After a crash up to CheckpointInterval-1 items are emitted again. It must be called before the first Next.
+// The limit applies to the items emitted by this iterator, not counting the ones
+// emitted before the resume. A checkpoint of a finished stream is ignored, so the
+// iteration starts from scratch.
+func (it *ListIterator) WithCheckpointer(checkpointer Checkpointer) *ListIterator {
+	it.checkpointer = checkpointer
+	return it
+}
+
+// Next advances to the next list, fetching a new page when needed.
+// It returns false when the results are exhausted, the limit is reached or an error occurred.
+func (it *ListIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		if err := it.resume(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	if it.done || it.err != nil {
+		return false
+	}
+	if it.listsNbr >= it.maxListsNbr {
+		it.stop()
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		it.save(false)
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if it.fetched {
+			// the whole page was emitted, resume from the next one
+			it.pageCursor = it.cursor
+			it.offset = 0
+			if it.cursor == "" {
+				it.done = true
+				it.save(true)
+				return false
+			}
+			if !it.save(false) {
+				return false
+			}
+			if err := it.ctx.Err(); err != nil {
+				it.err = err
+				return false
+			}
+		}
+
+		lists, next, err := it.fetchFunc(it.query, it.maxListsNbr, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(lists) == 0 || (it.fetched && next == it.cursor) {
+			it.done = true
+			it.save(true)
+			return false
+		}
+
+		it.fetched = true
+		it.page = lists
+		it.pageCursor = it.cursor
+		it.cursor = next
+		if it.skip > 0 {
+			skip := it.skip
+			if skip > len(it.page) {
+				skip = len(it.page)
+			}
+			it.page = it.page[skip:]
+			it.offset = skip
+			it.skip = 0
+		}
+	}
+
+	it.list = it.page[0]
+	it.page = it.page[1:]
+	it.offset++
+	it.listsNbr++
+	// a crash mid-page emits again only the lists since the last save
+	if it.listsNbr%CheckpointInterval != 0 {
+		return true
+	}
+	return it.save(false)
+}
+
+func (it *ListIterator) resume() error {
+	if it.checkpointer == nil {
+		return nil
+	}
+	checkpoint, err := it.checkpointer.Load(it.operation, it.query)
+	if err != nil || checkpoint == nil || checkpoint.Done {
+		return err
+	}
+	it.cursor = checkpoint.Cursor
+	it.pageCursor = checkpoint.Cursor
+	it.skip = checkpoint.Offset
+	it.resumedNbr = checkpoint.Count
+	return nil
+}
+
+// save stores the current position, false is returned if the checkpointer failed.
+func (it *ListIterator) save(done bool) bool {
+	if it.checkpointer == nil {
+		return true
+	}
+	err := it.checkpointer.Save(Checkpoint{
+		Operation: it.operation,
+		Query:     it.query,
+		Cursor:    it.pageCursor,
+		Offset:    it.offset,
+		Count:     it.resumedNbr + it.listsNbr,
+		Done:      done,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *ListIterator) stop() {
+	if it.started && !it.done && it.err == nil {
+		it.save(false)
+	}
+	it.done = true
+	it.page = nil
+}
+
+// List returns the current list.
+func (it *ListIterator) List() *List {
+	return it.list
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the cursor of the page following the current one.
+// Lists left on the current page are not covered by it.
+func (it *ListIterator) Cursor() string {
+	return it.cursor
+}
+
+// Close stops the iteration, no more pages are fetched after it.
+// The position is saved if a checkpointer is used.
+func (it *ListIterator) Close() {
+	it.stop()
+}
+
+// Chan returns channel with the remaining lists, like the Get methods do.
+// It ends with an error result if the iteration fails or the context is cancelled.
+// After the context is cancelled no more lists are sent, drain the channel to
+// receive the cancellation error and let the goroutine filling it exit.
+func (it *ListIterator) Chan() <-chan *ListResult {
+	channel := make(chan *ListResult)
+	go func() {
+		defer close(channel)
+		defer it.Close()
+		for it.Next() {
+			select {
+			case channel <- &ListResult{List: *it.List()}:
+			case <-it.ctx.Done():
+				it.err = it.ctx.Err()
+			}
+			if it.err != nil {
+				break
+			}
+		}
+		if err := it.Err(); err != nil {
+			channel <- &ListResult{Error: err}
+		}
+	}()
+	return channel
+}
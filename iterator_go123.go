@@ -0,0 +1,54 @@
+//go:build go1.23
+// +build go1.23
+
+package twitterscraper
+
+import "iter"
+
+// All returns the remaining tweets as a sequence for range-over-func loops.
+// The iteration error, if any, is yielded as the last element.
+func (it *TweetIterator) All() iter.Seq2[*Tweet, error] {
+	return func(yield func(*Tweet, error) bool) {
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Tweet(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// All returns the remaining profiles as a sequence for range-over-func loops.
+// The iteration error, if any, is yielded as the last element.
+func (it *ProfileIterator) All() iter.Seq2[*Profile, error] {
+	return func(yield func(*Profile, error) bool) {
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Profile(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// All returns the remaining lists as a sequence for range-over-func loops.
+// The iteration error, if any, is yielded as the last element.
+func (it *ListIterator) All() iter.Seq2[*List, error] {
+	return func(yield func(*List, error) bool) {
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.List(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
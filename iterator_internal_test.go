@@ -0,0 +1,218 @@
+package twitterscraper
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type fakePage struct {
+	ids  []string
+	next string
+}
+
+// fakeTimeline serves pages by cursor and counts the requests.
+func fakeTimeline(pages map[string]fakePage, requests *int) fetchTweetFunc {
+	return func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		*requests++
+		page := pages[cursor]
+		tweets := make([]*Tweet, 0, len(page.ids))
+		for _, id := range page.ids {
+			tweets = append(tweets, &Tweet{ID: id, EntryType: EntryOrganic})
+		}
+		return tweets, page.next, nil
+	}
+}
+
+func readIDs(it *TweetIterator, n int) []string {
+	var ids []string
+	for len(ids) < n && it.Next() {
+		ids = append(ids, it.Tweet().ID)
+	}
+	return ids
+}
+
+func TestTweetIteratorResumeAfterCrash(t *testing.T) {
+	var first []string
+	for i := 0; i < 25; i++ {
+		first = append(first, "t"+strconv.Itoa(i))
+	}
+	pages := map[string]fakePage{
+		"":      {ids: first, next: "page2"},
+		"page2": {ids: []string{"t5", "t25", "t26"}, next: "page3"},
+		"page3": {},
+	}
+	var requests int
+	fetch := fakeTimeline(pages, &requests)
+	checkpointer := NewMemoryCheckpointer()
+	scraper := New()
+
+	// the first run crashes mid-page: the iterator is neither drained nor closed
+	crashed := scraper.newTweetIterator(context.Background(), OperationTweets, "x", 100, fetch).WithCheckpointer(checkpointer)
+	if ids := readIDs(crashed, 22); !reflect.DeepEqual(ids, first[:22]) {
+		t.Fatalf("Expected first 22 tweets, got: %v", ids)
+	}
+
+	checkpoint, err := checkpointer.Load(OperationTweets, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint == nil || checkpoint.Cursor != "" || checkpoint.Offset != CheckpointInterval || checkpoint.Count != CheckpointInterval {
+		t.Fatalf("Expected checkpoint saved after %d tweets of the first page, got: %#v", CheckpointInterval, checkpoint)
+	}
+
+	resumed := scraper.newTweetIterator(context.Background(), OperationTweets, "x", 100, fetch).WithCheckpointer(checkpointer)
+	ids := readIDs(resumed, 100)
+	if err := resumed.Err(); err != nil {
+		t.Fatal(err)
+	}
+	// t20 and t21 were emitted after the last save and are emitted again,
+	// t5 is repeated on the second page and must be dropped by the restored seen set
+	if expected := []string{"t20", "t21", "t22", "t23", "t24", "t25", "t26"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected resumed tweets %v, got: %v", expected, ids)
+	}
+
+	checkpoint, _ = checkpointer.Load(OperationTweets, "x")
+	if checkpoint == nil || !checkpoint.Done || checkpoint.Count != 27 {
+		t.Errorf("Expected done checkpoint with count 27, got: %#v", checkpoint)
+	}
+
+	// a finished stream starts from scratch when asked again
+	again := scraper.newTweetIterator(context.Background(), OperationTweets, "x", 2, fetch).WithCheckpointer(checkpointer)
+	if ids := readIDs(again, 100); !reflect.DeepEqual(ids, []string{"t0", "t1"}) {
+		t.Errorf("Expected restarted tweets t0, t1, got: %v", ids)
+	}
+	again.Close()
+
+	// the limit applies per call, the next call continues where the last one stopped
+	next := scraper.newTweetIterator(context.Background(), OperationTweets, "x", 2, fetch).WithCheckpointer(checkpointer)
+	if ids := readIDs(next, 100); !reflect.DeepEqual(ids, []string{"t2", "t3"}) {
+		t.Errorf("Expected continued tweets t2, t3, got: %v", ids)
+	}
+}
+
+func TestTweetIteratorCheckpointKeyedByOptions(t *testing.T) {
+	pages := map[string]fakePage{
+		"": {ids: []string{"9", "8", "7"}},
+	}
+	var requests int
+	fetch := fakeTimeline(pages, &requests)
+	checkpointer := NewMemoryCheckpointer()
+	scraper := New()
+
+	it := scraper.newTweetIterator(context.Background(), OperationTweets, "x", 1, fetch).WithCheckpointer(checkpointer)
+	readIDs(it, 100)
+	it.Close()
+
+	bounded := scraper.newTweetIterator(context.Background(), OperationTweets, "x", 100, fetch).
+		WithOptions(TimelineOptions{MaxID: "8"}).
+		WithCheckpointer(checkpointer)
+	if ids := readIDs(bounded, 100); !reflect.DeepEqual(ids, []string{"8", "7"}) {
+		t.Errorf("Expected bounded stream to ignore unbounded checkpoint, got: %v", ids)
+	}
+	if checkpoint, _ := checkpointer.Load(OperationTweets, "x?max_id=8"); checkpoint == nil || !checkpoint.Done {
+		t.Errorf("Expected done checkpoint for bounded stream, got: %#v", checkpoint)
+	}
+}
+
+func TestSearchTweetsCheckpointKeyedByProduct(t *testing.T) {
+	scraper := New()
+	it := scraper.IterSearchTweetsWithOptions(context.Background(), "golang", 10, SearchOptions{Product: SearchProductLatest, PageSize: 50}).
+		WithOptions(TimelineOptions{SinceID: "1"})
+	if query := it.checkpointQuery(); query != "golang?page_size=50&product=Latest&since_id=1" {
+		t.Errorf("Expected product, page size and options in the key, got %q", query)
+	}
+	if query := scraper.IterSearchTweets(context.Background(), "golang", 10).checkpointQuery(); query != "golang?product=Top" {
+		t.Errorf("Expected default search mode product in the key, got %q", query)
+	}
+}
+
+func TestListIteratorChan(t *testing.T) {
+	var requests int
+	fetch := func(query string, maxListsNbr int, cursor string) ([]*List, string, error) {
+		requests++
+		if cursor == "" {
+			return []*List{{ID: "1"}, {ID: "2"}}, "page2", nil
+		}
+		return []*List{{ID: "3"}}, "", nil
+	}
+	scraper := New()
+
+	var ids []string
+	for list := range scraper.newListIterator(context.Background(), OperationSearchLists, "go", 10, fetch).Chan() {
+		if list.Error != nil {
+			t.Fatal(list.Error)
+		}
+		ids = append(ids, list.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "2", "3"}) || requests != 2 {
+		t.Errorf("Expected lists 1, 2, 3 in 2 requests, got %v in %d", ids, requests)
+	}
+
+	// a reader stopping early cancels the context and gets the cancellation error last
+	ctx, cancel := context.WithCancel(context.Background())
+	channel := scraper.newListIterator(ctx, OperationSearchLists, "go", 10, fetch).Chan()
+	<-channel
+	cancel()
+	var last *ListResult
+	for list := range channel {
+		last = list
+	}
+	if last == nil || last.Error != context.Canceled {
+		t.Errorf("Expected cancellation error last, got %#v", last)
+	}
+}
+
+func TestTweetIteratorChanCancel(t *testing.T) {
+	pages := map[string]fakePage{
+		"":      {ids: []string{"3", "2"}, next: "page2"},
+		"page2": {ids: []string{"1"}},
+	}
+	// a send and the cancellation are both ready, the error must never be dropped
+	for i := 0; i < 50; i++ {
+		var requests int
+		ctx, cancel := context.WithCancel(context.Background())
+		channel := New().newTweetIterator(ctx, OperationTweets, "x", 100, fakeTimeline(pages, &requests)).Chan()
+		if tweet := <-channel; tweet.Error != nil || tweet.ID != "3" {
+			t.Fatalf("Expected first tweet 3, got: %#v", tweet)
+		}
+		cancel()
+		var last *TweetResult
+		for tweet := range channel {
+			last = tweet
+		}
+		if last == nil || last.Error != context.Canceled {
+			t.Fatalf("Expected cancellation error last, got %#v", last)
+		}
+		if requests > 1 {
+			t.Fatalf("Expected no more pages after cancel, got %d requests", requests)
+		}
+	}
+}
+
+func TestIteratorsStopBetweenPages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var requests int
+	profiles := New().newProfileIterator(ctx, OperationFollowers, "x", 10, func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
+		requests++
+		return []*Profile{{UserID: cursor + "1"}}, cursor + "next", nil
+	})
+	if !profiles.Next() {
+		t.Fatal(profiles.Err())
+	}
+	cancel()
+	if profiles.Next() || profiles.Err() != context.Canceled || requests != 1 {
+		t.Errorf("Expected profile iterator stops before the next page, got %v after %d requests", profiles.Err(), requests)
+	}
+
+	requests = 0
+	lists := New().newListIterator(ctx, OperationSearchLists, "x", 10, func(query string, maxListsNbr int, cursor string) ([]*List, string, error) {
+		requests++
+		return []*List{{ID: cursor + "1"}}, cursor + "next", nil
+	})
+	// the context is cancelled before the first page too
+	if lists.Next() || lists.Err() != context.Canceled || requests != 0 {
+		t.Errorf("Expected list iterator doesn't fetch with a cancelled context, got %v after %d requests", lists.Err(), requests)
+	}
+}
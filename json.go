@@ -0,0 +1,303 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONSchemaVersion is the version of the JSON representation of Tweet, Profile and Space
+// described by schema/twitter-scraper.schema.json. It is increased on breaking changes.
+const JSONSchemaVersion = 1
+
+var entryTypeNames = map[EntryType]string{
+	EntryOrganic:       "organic",
+	EntryPromoted:      "promoted",
+	EntryModuleContext: "module_context",
+}
+
+// MarshalText encodes the entry type as organic, promoted or module_context.
+func (entryType EntryType) MarshalText() ([]byte, error) {
+	if name, ok := entryTypeNames[entryType]; ok {
+		return []byte(name), nil
+	}
+	return nil, fmt.Errorf("unknown entry type %d", int(entryType))
+}
+
+// UnmarshalText decodes the entry type from its name.
+func (entryType *EntryType) UnmarshalText(text []byte) error {
+	for value, name := range entryTypeNames {
+		if name == string(text) {
+			*entryType = value
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown entry type %q", text)
+}
+
+var unavailableReasonNames = map[UnavailableReason]string{
+	UnavailableUnknown:           "unknown",
+	UnavailableDeleted:           "deleted",
+	UnavailableSuspended:         "suspended",
+	UnavailableProtected:         "protected",
+	UnavailableWithheld:          "withheld",
+	UnavailableAgeRestricted:     "age_restricted",
+	UnavailableLimitedVisibility: "limited_visibility",
+}
+
+// MarshalText encodes the reason as unknown, deleted, suspended, protected, withheld,
+// age_restricted or limited_visibility.
+func (reason UnavailableReason) MarshalText() ([]byte, error) {
+	if name, ok := unavailableReasonNames[reason]; ok {
+		return []byte(name), nil
+	}
+	return nil, fmt.Errorf("unknown unavailable reason %d", int(reason))
+}
+
+// UnmarshalText decodes the reason from its name.
+func (reason *UnavailableReason) UnmarshalText(text []byte) error {
+	for value, name := range unavailableReasonNames {
+		if name == string(text) {
+			*reason = value
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown unavailable reason %q", text)
+}
+
+func checkSchemaVersion(version int) error {
+	if version > JSONSchemaVersion {
+		return fmt.Errorf("unsupported schema version %d, the latest supported is %d", version, JSONSchemaVersion)
+	}
+	return nil
+}
+
+type tweetAlias Tweet
+
+// tweetJSON hides related tweets of the embedded tweet, they are referenced by ID
+// unless expanded.
+type tweetJSON struct {
+	SchemaVersion int `json:"schema_version"`
+	*tweetAlias
+	InReplyToStatus *Tweet   `json:"in_reply_to_status,omitempty"`
+	QuotedStatus    *Tweet   `json:"quoted_status,omitempty"`
+	RetweetedStatus *Tweet   `json:"retweeted_status,omitempty"`
+	Thread          []*Tweet `json:"thread,omitempty"`
+	ThreadIDs       []string `json:"thread_ids,omitempty"`
+}
+
+func newTweetJSON(tweet *Tweet, expand bool) tweetJSON {
+	data := tweetJSON{
+		SchemaVersion: JSONSchemaVersion,
+		tweetAlias:    (*tweetAlias)(tweet),
+	}
+	if expand {
+		data.InReplyToStatus = tweet.InReplyToStatus
+		data.QuotedStatus = tweet.QuotedStatus
+		data.RetweetedStatus = tweet.RetweetedStatus
+		data.Thread = tweet.Thread
+		return data
+	}
+	for _, threadTweet := range tweet.Thread {
+		if threadTweet != nil {
+			data.ThreadIDs = append(data.ThreadIDs, threadTweet.ID)
+		}
+	}
+	return data
+}
+
+// MarshalJSON encodes the tweet with snake_case keys and RFC 3339 times.
+// Related tweets are referenced by in_reply_to_status_id, quoted_status_id,
+// retweeted_status_id and thread_ids, use ExpandedTweet to include them.
+func (tweet Tweet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newTweetJSON(&tweet, false))
+}
+
+// UnmarshalJSON decodes a tweet encoded by MarshalJSON or ExpandedTweet.
+// Tweets referenced only by thread_ids are restored with just their ID.
+func (tweet *Tweet) UnmarshalJSON(data []byte) error {
+	var decoded Tweet
+	aux := tweetJSON{tweetAlias: (*tweetAlias)(&decoded)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+	decoded.InReplyToStatus = aux.InReplyToStatus
+	decoded.QuotedStatus = aux.QuotedStatus
+	decoded.RetweetedStatus = aux.RetweetedStatus
+	decoded.Thread = aux.Thread
+	if decoded.Thread == nil {
+		for _, id := range aux.ThreadIDs {
+			decoded.Thread = append(decoded.Thread, &Tweet{ID: id})
+		}
+	}
+	*tweet = decoded
+	return nil
+}
+
+// ExpandedTweet encodes a tweet with its reply, quoted and retweeted tweets and thread inline.
+// Inlined tweets reference their own related tweets by ID.
+type ExpandedTweet struct {
+	*Tweet
+}
+
+// MarshalJSON encodes the tweet with related tweets inline.
+func (tweet ExpandedTweet) MarshalJSON() ([]byte, error) {
+	if tweet.Tweet == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(newTweetJSON(tweet.Tweet, true))
+}
+
+type profileAlias Profile
+
+type profileJSON struct {
+	SchemaVersion int `json:"schema_version"`
+	*profileAlias
+}
+
+// MarshalJSON encodes the profile with snake_case keys and RFC 3339 times.
+func (profile Profile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(profileJSON{JSONSchemaVersion, (*profileAlias)(&profile)})
+}
+
+// UnmarshalJSON decodes a profile encoded by MarshalJSON.
+func (profile *Profile) UnmarshalJSON(data []byte) error {
+	var decoded Profile
+	aux := profileJSON{profileAlias: (*profileAlias)(&decoded)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+	*profile = decoded
+	return nil
+}
+
+// resultError is the JSON of a failed result.
+type resultError struct {
+	Error string `json:"error,omitempty"`
+}
+
+func decodeResultError(data []byte) (string, error) {
+	var aux resultError
+	err := json.Unmarshal(data, &aux)
+	return aux.Error, err
+}
+
+// MarshalJSON encodes the tweet like Tweet does, or only {"error": "..."} if Error is set.
+func (result TweetResult) MarshalJSON() ([]byte, error) {
+	if result.Error != nil {
+		return json.Marshal(resultError{result.Error.Error()})
+	}
+	return json.Marshal(result.Tweet)
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON.
+func (result *TweetResult) UnmarshalJSON(data []byte) error {
+	message, err := decodeResultError(data)
+	if err != nil {
+		return err
+	}
+	if message != "" {
+		*result = TweetResult{Error: errors.New(message)}
+		return nil
+	}
+	var decoded TweetResult
+	if err := decoded.Tweet.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*result = decoded
+	return nil
+}
+
+// MarshalJSON encodes the profile like Profile does, or only {"error": "..."} if Error is set.
+func (result ProfileResult) MarshalJSON() ([]byte, error) {
+	if result.Error != nil {
+		return json.Marshal(resultError{result.Error.Error()})
+	}
+	return json.Marshal(result.Profile)
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON.
+func (result *ProfileResult) UnmarshalJSON(data []byte) error {
+	message, err := decodeResultError(data)
+	if err != nil {
+		return err
+	}
+	if message != "" {
+		*result = ProfileResult{Error: errors.New(message)}
+		return nil
+	}
+	var decoded ProfileResult
+	if err := decoded.Profile.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*result = decoded
+	return nil
+}
+
+// fanOutResultJSON nests the tweet, its own username and user_id would clash with the target ones.
+type fanOutResultJSON struct {
+	Username string `json:"username"`
+	UserID   string `json:"user_id"`
+	Tweet    *Tweet `json:"tweet,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes the target username and user_id with either the tweet or the error.
+func (result FanOutResult) MarshalJSON() ([]byte, error) {
+	aux := fanOutResultJSON{Username: result.Username, UserID: result.UserID}
+	if result.Error != nil {
+		aux.Error = result.Error.Error()
+	} else {
+		aux.Tweet = &result.Tweet
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes a result encoded by MarshalJSON.
+func (result *FanOutResult) UnmarshalJSON(data []byte) error {
+	var aux fanOutResultJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	decoded := FanOutResult{Username: aux.Username, UserID: aux.UserID}
+	if aux.Error != "" {
+		decoded.Error = errors.New(aux.Error)
+	}
+	if aux.Tweet != nil {
+		decoded.Tweet = *aux.Tweet
+	}
+	*result = decoded
+	return nil
+}
+
+type spaceAlias Space
+
+type spaceJSON struct {
+	SchemaVersion int `json:"schema_version"`
+	*spaceAlias
+}
+
+// MarshalJSON encodes the space with snake_case keys and RFC 3339 times.
+func (space Space) MarshalJSON() ([]byte, error) {
+	return json.Marshal(spaceJSON{JSONSchemaVersion, (*spaceAlias)(&space)})
+}
+
+// UnmarshalJSON decodes a space encoded by MarshalJSON.
+func (space *Space) UnmarshalJSON(data []byte) error {
+	var decoded Space
+	aux := spaceJSON{spaceAlias: (*spaceAlias)(&decoded)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if err := checkSchemaVersion(aux.SchemaVersion); err != nil {
+		return err
+	}
+	*space = decoded
+	return nil
+}
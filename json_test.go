@@ -0,0 +1,211 @@
+package twitterscraper_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func sampleJSONTweet() twitterscraper.Tweet {
+	created := time.Date(2023, 8, 31, 17, 45, 31, 0, time.UTC)
+	joined := time.Date(2007, 2, 20, 14, 35, 54, 0, time.UTC)
+	return twitterscraper.Tweet{
+		Author:            &twitterscraper.Profile{UserID: "783214", Username: "X", Name: "X", Joined: &joined, FollowersCount: 10},
+		Card:              &twitterscraper.Card{Name: "poll2choice_text_only", Poll: &twitterscraper.Poll{Choices: []twitterscraper.PollChoice{{Label: "Yes", Count: 1}}}},
+		ConversationID:    "1697304622749086011",
+		DisplayTextRange:  [2]int{0, 4},
+		EntryType:         twitterscraper.EntryPromoted,
+		ID:                "1697304622749086011",
+		InReplyToStatus:   &twitterscraper.Tweet{ID: "1697304622749086010", Text: "parent"},
+		InReplyToStatusID: "1697304622749086010",
+		IsReply:           true,
+		Media:             []twitterscraper.TweetMedia{{ID: "1", Type: "video", Duration: 1500 * time.Millisecond}},
+		Text:              "test",
+		Thread:            []*twitterscraper.Tweet{{ID: "1697304622749086012", Text: "next"}},
+		TimeParsed:        created,
+		Timestamp:         created.Unix(),
+		UserID:            "783214",
+		Username:          "X",
+	}
+}
+
+func TestTweetJSON(t *testing.T) {
+	tweet := sampleJSONTweet()
+	data, err := json.Marshal(tweet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys map[string]interface{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		t.Fatal(err)
+	}
+	if keys["schema_version"] != float64(twitterscraper.JSONSchemaVersion) {
+		t.Errorf("Expected schema_version %d, got %v", twitterscraper.JSONSchemaVersion, keys["schema_version"])
+	}
+	if keys["created_at"] != "2023-08-31T17:45:31Z" || keys["entry_type"] != "promoted" {
+		t.Errorf("Unexpected created_at %v or entry_type %v", keys["created_at"], keys["entry_type"])
+	}
+	if _, ok := keys["in_reply_to_status"]; ok {
+		t.Error("Expected related tweets are referenced by ID")
+	}
+	if ids, ok := keys["thread_ids"].([]interface{}); !ok || len(ids) != 1 || ids[0] != "1697304622749086012" {
+		t.Errorf("Expected thread_ids, got %v", keys["thread_ids"])
+	}
+
+	var decoded twitterscraper.Tweet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	expected := tweet
+	expected.InReplyToStatus = nil
+	expected.Thread = []*twitterscraper.Tweet{{ID: "1697304622749086012"}}
+	if diff := cmp.Diff(expected, decoded); diff != "" {
+		t.Error("Decoded tweet does not match", diff)
+	}
+}
+
+func TestExpandedTweetJSON(t *testing.T) {
+	tweet := sampleJSONTweet()
+	data, err := json.Marshal(twitterscraper.ExpandedTweet{Tweet: &tweet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded twitterscraper.Tweet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(tweet, decoded); diff != "" {
+		t.Error("Decoded expanded tweet does not match", diff)
+	}
+}
+
+func TestJSONSchemaVersion(t *testing.T) {
+	var profile twitterscraper.Profile
+	if err := json.Unmarshal([]byte(`{"schema_version":1000,"username":"X"}`), &profile); err == nil {
+		t.Error("Expected error for unsupported schema version")
+	}
+	if err := json.Unmarshal([]byte(`{"schema_version":1,"username":"X"}`), &profile); err != nil || profile.Username != "X" {
+		t.Errorf("Expected profile is decoded, got %v %v", profile, err)
+	}
+}
+
+// TestJSONSchemaFile checks that the schema file describes every field of the encoded types.
+func TestJSONSchemaFile(t *testing.T) {
+	content, err := os.ReadFile("schema/twitter-scraper.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Defs map[string]struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[reflect.Type]bool)
+	var check func(typ reflect.Type)
+	check = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || typ.Name() == "" || typ == reflect.TypeOf(time.Time{}) || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		def, ok := schema.Defs[typ.Name()]
+		if !ok {
+			t.Errorf("Schema has no definition of %s", typ.Name())
+			return
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			key := strings.Split(field.Tag.Get("json"), ",")[0]
+			if key == "" || key == "-" {
+				t.Errorf("Field %s.%s has no JSON key", typ.Name(), field.Name)
+				continue
+			}
+			if _, ok := def.Properties[key]; !ok {
+				t.Errorf("Schema of %s has no property %s", typ.Name(), key)
+			}
+			check(field.Type)
+		}
+	}
+	check(reflect.TypeOf(twitterscraper.Tweet{}))
+	check(reflect.TypeOf(twitterscraper.Profile{}))
+	check(reflect.TypeOf(twitterscraper.Space{}))
+}
+
+func TestUnavailableJSON(t *testing.T) {
+	tweet := twitterscraper.Tweet{
+		ID:          "1697304622749086010",
+		Unavailable: &twitterscraper.Unavailable{Reason: twitterscraper.UnavailableAgeRestricted, Text: "Age-restricted adult content."},
+	}
+	data, err := json.Marshal(tweet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"unavailable":{"reason":"age_restricted"`) {
+		t.Errorf("Expected unavailable reason is encoded by name, got %s", data)
+	}
+
+	var decoded twitterscraper.Tweet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(tweet, decoded); diff != "" {
+		t.Error("Decoded tweet does not match", diff)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"1","unavailable":{"reason":"gone"}}`), &decoded); err == nil {
+		t.Error("Expected error for unknown unavailable reason")
+	}
+}
+
+func TestResultJSON(t *testing.T) {
+	tweet := sampleJSONTweet()
+	tweet.InReplyToStatus = nil
+	tweet.Thread = nil
+	results := []interface{}{
+		&twitterscraper.TweetResult{Tweet: tweet},
+		&twitterscraper.TweetResult{Error: errors.New("rate limited")},
+		&twitterscraper.ProfileResult{Profile: *tweet.Author},
+		&twitterscraper.ProfileResult{Error: errors.New("not found")},
+		&twitterscraper.FanOutResult{TweetResult: twitterscraper.TweetResult{Tweet: tweet}, Username: "X", UserID: "783214"},
+		&twitterscraper.FanOutResult{TweetResult: twitterscraper.TweetResult{Error: errors.New("suspended")}, Username: "Y", UserID: "1"},
+	}
+	compareErrors := cmp.Comparer(func(a, b error) bool {
+		return (a == nil) == (b == nil) && (a == nil || a.Error() == b.Error())
+	})
+	for _, result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := reflect.New(reflect.TypeOf(result).Elem()).Interface()
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(result, decoded, compareErrors); diff != "" {
+			t.Errorf("Decoded %T does not match %s: %s", result, data, diff)
+		}
+	}
+
+	data, _ := json.Marshal(twitterscraper.TweetResult{Error: errors.New("rate limited")})
+	if string(data) != `{"error":"rate limited"}` {
+		t.Errorf("Expected only error is encoded, got %s", data)
+	}
+	data, _ = json.Marshal(twitterscraper.FanOutResult{TweetResult: twitterscraper.TweetResult{Tweet: tweet}, Username: "X", UserID: "783214"})
+	if !strings.HasPrefix(string(data), `{"username":"X","user_id":"783214","tweet":{`) {
+		t.Errorf("Expected target is encoded with nested tweet, got %s", data)
+	}
+}
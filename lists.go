@@ -0,0 +1,72 @@
+package twitterscraper
+
+import (
+	"context"
+	"net/url"
+)
+
+// GetListTweets returns channel with latest tweets of a list.
+func (s *Scraper) GetListTweets(ctx context.Context, listID string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.getTweetTimeline(ctx, OperationListTweets, listID, maxTweetsNbr, s.FetchListTweets)
+}
+
+// IterListTweets returns iterator over latest tweets of a list.
+func (s *Scraper) IterListTweets(ctx context.Context, listID string, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationListTweets, listID, maxTweetsNbr, s.FetchListTweets)
+}
+
+// FetchListTweets gets latest tweets of a list via the Twitter frontend GraphQL API.
+func (s *Scraper) FetchListTweets(listID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	if maxTweetsNbr > 200 {
+		maxTweetsNbr = 200
+	}
+
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/HjsWc-nwwHKYwHenbHm-tw/ListLatestTweetsTimeline")
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"listId": listID,
+		"count":  maxTweetsNbr,
+	}
+	features := map[string]interface{}{
+		"rweb_lists_timeline_redesign_enabled":                                    true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"tweetypie_unmention_optimization_enabled":                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                false,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(features))
+	req.URL.RawQuery = query.Encode()
+
+	var timeline listTimelineV2
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tweets, nextCursor := timeline.parseTweets()
+	return tweets, nextCursor, nil
+}
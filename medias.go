@@ -7,7 +7,12 @@ import (
 
 // GetTweets returns channel with tweets for a given user.
 func (s *Scraper) GetMediaTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchMediaTweets)
+	return s.getTweetTimeline(ctx, OperationMediaTweets, user, maxTweetsNbr, s.FetchMediaTweets)
+}
+
+// IterMediaTweets returns iterator over tweets with medias for a given user.
+func (s *Scraper) IterMediaTweets(ctx context.Context, user string, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationMediaTweets, user, maxTweetsNbr, s.FetchMediaTweets)
 }
 
 // FetchMediaTweets gets tweets with medias for a given user, via the Twitter frontend API.
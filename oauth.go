@@ -0,0 +1,275 @@
+package twitterscraper
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	oAuthRequestTokenURL = "https://api.twitter.com/oauth/request_token"
+	oAuthAuthorizeURL    = "https://api.twitter.com/oauth/authorize"
+	oAuthAccessTokenURL  = "https://api.twitter.com/oauth/access_token"
+)
+
+// OAuthConsumer is the key and secret of a registered Twitter app.
+type OAuthConsumer struct {
+	Key    string
+	Secret string
+}
+
+// OAuthRequestToken is a temporary token returned by RequestOAuthToken
+// that must be authorized by the user before it can be exchanged.
+type OAuthRequestToken struct {
+	OAuthToken       string
+	OAuthTokenSecret string
+	// AuthorizeURL is the page where the user approves the app and gets a PIN.
+	AuthorizeURL string
+}
+
+// WithOAuthConsumer sets the app used to sign OAuth 1.0a requests.
+// By default requests are signed with the built-in Twitter app.
+func (s *Scraper) WithOAuthConsumer(consumer OAuthConsumer) *Scraper {
+	s.consumerKey = consumer.Key
+	s.consumerSecret = consumer.Secret
+	return s
+}
+
+// RequestOAuthToken starts the PIN-based OAuth flow for the configured consumer.
+// Open AuthorizeURL of the result in a browser and pass the PIN to AccessOAuthToken.
+func (s *Scraper) RequestOAuthToken() (OAuthRequestToken, error) {
+	values, err := s.oAuthRequest(oAuthRequestTokenURL, "", "", nil, map[string]string{
+		"oauth_callback": "oob",
+	})
+	if err != nil {
+		return OAuthRequestToken{}, err
+	}
+
+	token := OAuthRequestToken{
+		OAuthToken:       values.Get("oauth_token"),
+		OAuthTokenSecret: values.Get("oauth_token_secret"),
+	}
+	if token.OAuthToken == "" || token.OAuthTokenSecret == "" {
+		return OAuthRequestToken{}, fmt.Errorf("auth error: %v", "Token or Secret is empty")
+	}
+	token.AuthorizeURL = oAuthAuthorizeURL + "?oauth_token=" + url.QueryEscape(token.OAuthToken)
+	return token, nil
+}
+
+// AccessOAuthToken exchanges an authorized request token and PIN for an access token
+// and logs the scraper in with it.
+func (s *Scraper) AccessOAuthToken(requestToken OAuthRequestToken, pin string) (OpenAccount, error) {
+	values, err := s.oAuthRequest(oAuthAccessTokenURL, requestToken.OAuthToken, requestToken.OAuthTokenSecret, nil, map[string]string{
+		"oauth_verifier": pin,
+	})
+	if err != nil {
+		return OpenAccount{}, err
+	}
+	return s.withOAuthAccessToken(values)
+}
+
+// LoginXAuth logs in with username and password using xAuth.
+// The configured consumer must have xAuth access granted by Twitter.
+func (s *Scraper) LoginXAuth(username, password string) (OpenAccount, error) {
+	form := url.Values{}
+	form.Set("x_auth_username", username)
+	form.Set("x_auth_password", password)
+	form.Set("x_auth_mode", "client_auth")
+
+	values, err := s.oAuthRequest(oAuthAccessTokenURL, "", "", form, nil)
+	if err != nil {
+		return OpenAccount{}, err
+	}
+	return s.withOAuthAccessToken(values)
+}
+
+func (s *Scraper) withOAuthAccessToken(values url.Values) (OpenAccount, error) {
+	account := OpenAccount{
+		OAuthToken:       values.Get("oauth_token"),
+		OAuthTokenSecret: values.Get("oauth_token_secret"),
+	}
+	if account.OAuthToken == "" || account.OAuthTokenSecret == "" {
+		return OpenAccount{}, fmt.Errorf("auth error: %v", "Token or Secret is empty")
+	}
+	s.oAuthToken = account.OAuthToken
+	s.oAuthSecret = account.OAuthTokenSecret
+	s.isLogged = true
+	s.isOpenAccount = true
+	return account, nil
+}
+
+// oAuthRequest makes a signed form POST to one of the OAuth endpoints and parses the form-encoded answer.
+func (s *Scraper) oAuthRequest(endpoint, token, tokenSecret string, form url.Values, extra map[string]string) (url.Values, error) {
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set("Authorization", s.oAuthHeader(req.Method, req.URL, form, token, tokenSecret, extra))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response status %s: %s", resp.Status, body)
+	}
+
+	return url.ParseQuery(string(body))
+}
+
+// sign returns the OAuth 1.0a Authorization header for req using the session token.
+// Form-encoded body parameters are included in the signature as required by RFC 5849.
+func (s *Scraper) sign(req *http.Request) (string, error) {
+	var form url.Values
+	if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		if form, err = url.ParseQuery(string(body)); err != nil {
+			return "", err
+		}
+	}
+	return s.oAuthHeader(req.Method, req.URL, form, s.oAuthToken, s.oAuthSecret, nil), nil
+}
+
+func (s *Scraper) oAuthHeader(method string, ref *url.URL, form url.Values, token, tokenSecret string, extra map[string]string) string {
+	oauth := map[string]string{
+		"oauth_consumer_key":     s.consumerKey,
+		"oauth_nonce":            oAuthNonce(),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if token != "" {
+		oauth["oauth_token"] = token
+	}
+	for k, v := range extra {
+		oauth[k] = v
+	}
+
+	params := ref.Query()
+	for k, v := range form {
+		params[k] = append(params[k], v...)
+	}
+	for k, v := range oauth {
+		params.Set(k, v)
+	}
+
+	base := oauthSignatureBase(method, ref, params)
+	oauth["oauth_signature"] = oauthSignature(base, s.consumerSecret, tokenSecret)
+
+	keys := make([]string, 0, len(oauth))
+	for k := range oauth {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		if b.Len() > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(percentEncode(k))
+		b.WriteString(`="`)
+		b.WriteString(percentEncode(oauth[k]))
+		b.WriteByte('"')
+	}
+
+	return "OAuth " + b.String()
+}
+
+// oauthSignatureBase builds the signature base string described in RFC 5849 section 3.4.1.
+// params must contain the query, form body and oauth_* protocol parameters except oauth_signature.
+func oauthSignatureBase(method string, ref *url.URL, params url.Values) string {
+	type pair struct{ k, v string }
+	var pairs []pair
+	for k, values := range params {
+		for _, v := range values {
+			pairs = append(pairs, pair{percentEncode(k), percentEncode(v)})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].k != pairs[j].k {
+			return pairs[i].k < pairs[j].k
+		}
+		return pairs[i].v < pairs[j].v
+	})
+
+	var normalized strings.Builder
+	for _, p := range pairs {
+		if normalized.Len() > 0 {
+			normalized.WriteByte('&')
+		}
+		normalized.WriteString(p.k)
+		normalized.WriteByte('=')
+		normalized.WriteString(p.v)
+	}
+
+	scheme := strings.ToLower(ref.Scheme)
+	host := strings.ToLower(ref.Hostname())
+	if port := ref.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	path := ref.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.ToUpper(method) + "&" + percentEncode(scheme+"://"+host+path) + "&" + percentEncode(normalized.String())
+}
+
+// oauthSignature signs the base string with HMAC-SHA1 as described in RFC 5849 section 3.4.2.
+func oauthSignature(base, consumerSecret, tokenSecret string) string {
+	key := percentEncode(consumerSecret) + "&" + percentEncode(tokenSecret)
+	h := hmac.New(sha1.New, []byte(key))
+	h.Write([]byte(base))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// percentEncode escapes s with the RFC 3986 unreserved set, as required by RFC 5849 section 3.6.
+func percentEncode(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(hexDigits[c>>4])
+		b.WriteByte(hexDigits[c&15])
+	}
+	return b.String()
+}
+
+func oAuthNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,45 @@
+package twitterscraper
+
+import (
+	"net/url"
+	"testing"
+)
+
+// Examples are taken from RFC 5849 sections 1.2 and 3.4.1.
+func TestOAuthSignatureBase(t *testing.T) {
+	ref, _ := url.Parse("http://example.com/request?b5=%3D%253D&a3=a&c%40=&a2=r%20b")
+	params := ref.Query()
+	params.Add("c2", "")
+	params.Add("a3", "2 q")
+	params.Set("oauth_consumer_key", "9djdj82h48djs9d2")
+	params.Set("oauth_token", "kkk9d7dh3k39sjv7")
+	params.Set("oauth_signature_method", "HMAC-SHA1")
+	params.Set("oauth_timestamp", "137131201")
+	params.Set("oauth_nonce", "7d8f3e4a")
+
+	expected := "POST&http%3A%2F%2Fexample.com%2Frequest&a2%3Dr%2520b%26a3%3D2%2520q" +
+		"%26a3%3Da%26b5%3D%253D%25253D%26c%2540%3D%26c2%3D%26oauth_consumer_" +
+		"key%3D9djdj82h48djs9d2%26oauth_nonce%3D7d8f3e4a%26oauth_signature_m" +
+		"ethod%3DHMAC-SHA1%26oauth_timestamp%3D137131201%26oauth_token%3Dkkk" +
+		"9d7dh3k39sjv7"
+
+	if base := oauthSignatureBase("POST", ref, params); base != expected {
+		t.Errorf("oauthSignatureBase() = %s, want %s", base, expected)
+	}
+}
+
+func TestOAuthSignature(t *testing.T) {
+	ref, _ := url.Parse("http://photos.example.net/photos?file=vacation.jpg&size=original")
+	params := ref.Query()
+	params.Set("oauth_consumer_key", "dpf43f3p2l4k3l03")
+	params.Set("oauth_token", "nnch734d00sl2jdk")
+	params.Set("oauth_signature_method", "HMAC-SHA1")
+	params.Set("oauth_timestamp", "137131202")
+	params.Set("oauth_nonce", "chapoH")
+
+	base := oauthSignatureBase("GET", ref, params)
+	signature := oauthSignature(base, "kd94hf93k423kf44", "pfkkdhi9sl3r4s00")
+	if signature != "MdpQcU8iPSUjWoN/UDMsK2sui9I=" {
+		t.Errorf("oauthSignature() = %s, want %s", signature, "MdpQcU8iPSUjWoN/UDMsK2sui9I=")
+	}
+}
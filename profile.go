@@ -1,6 +1,7 @@
 package twitterscraper
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -14,39 +15,38 @@ var cacheIDs sync.Map
 
 // Profile of twitter user.
 type Profile struct {
-	Avatar         string
-	Banner         string
-	Biography      string
-	Birthday       string
-	FollowersCount int
-	FollowingCount int
-	FriendsCount   int
-	IsPrivate      bool
-	IsVerified     bool
-	Joined         *time.Time
-	LikesCount     int
-	ListedCount    int
-	Location       string
-	Name           string
-	PinnedTweetIDs []string
-	TweetsCount    int
-	URL            string
-	UserID         string
-	Username       string
-	Website        string
-	Sensitive      bool
-	Following      bool
-	FollowedBy     bool
+	Avatar         string          `json:"avatar"`
+	Banner         string          `json:"banner"`
+	Biography      string          `json:"biography"`
+	Birthday       string          `json:"birthday"`
+	FollowersCount int             `json:"followers_count"`
+	FollowingCount int             `json:"following_count"`
+	FriendsCount   int             `json:"friends_count"`
+	IsBlueVerified bool            `json:"is_blue_verified"`
+	IsPrivate      bool            `json:"is_private"`
+	IsVerified     bool            `json:"is_verified"`
+	Joined         *time.Time      `json:"joined,omitempty"`
+	LikesCount     int             `json:"likes_count"`
+	ListedCount    int             `json:"listed_count"`
+	Location       string          `json:"location"`
+	Name           string          `json:"name"`
+	PinnedTweetIDs []string        `json:"pinned_tweet_ids,omitempty"`
+	Raw            json.RawMessage `json:"raw,omitempty"`
+	TweetsCount    int             `json:"tweets_count"`
+	URL            string          `json:"url"`
+	UserID         string          `json:"user_id"`
+	Username       string          `json:"username"`
+	VerifiedType   string          `json:"verified_type"`
+	Website        string          `json:"website"`
+	Sensitive      bool            `json:"sensitive"`
+	Following      bool            `json:"following"`
+	FollowedBy     bool            `json:"followed_by"`
 }
 
 type user struct {
 	Data struct {
 		User struct {
-			Result struct {
-				RestID  string     `json:"rest_id"`
-				Legacy  legacyUser `json:"legacy"`
-				Message string     `json:"message"`
-			} `json:"result"`
+			Result legacyUserResult `json:"result"`
 		} `json:"user"`
 	} `json:"data"`
 	Errors []struct {
@@ -111,7 +111,7 @@ func (s *Scraper) GetProfile(username string) (Profile, error) {
 		return Profile{}, fmt.Errorf("either @%s does not exist or is private", username)
 	}
 
-	return parseProfile(jsn.Data.User.Result.Legacy), nil
+	return jsn.Data.User.Result.parse(), nil
 }
 
 func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
@@ -168,7 +168,7 @@ func (s *Scraper) GetProfileByID(userID string) (Profile, error) {
 		return Profile{}, fmt.Errorf("either @%s does not exist or is private", userID)
 	}
 
-	return parseProfile(jsn.Data.User.Result.Legacy), nil
+	return jsn.Data.User.Result.parse(), nil
 }
 
 // GetUserIDByScreenName from API
@@ -0,0 +1,67 @@
+package twitterscraper
+
+import "context"
+
+// GetTweetQuotes returns channel with latest tweets quoting a given tweet.
+func (s *Scraper) GetTweetQuotes(ctx context.Context, tweetID string, maxTweetsNbr int) <-chan *TweetResult {
+	return s.IterTweetQuotes(ctx, tweetID, maxTweetsNbr).Chan()
+}
+
+// IterTweetQuotes returns iterator over latest tweets quoting a given tweet.
+// The quoted tweet is fetched at most once for all pages.
+func (s *Scraper) IterTweetQuotes(ctx context.Context, tweetID string, maxTweetsNbr int) *TweetIterator {
+	var quoted quotedTweet
+	return s.newTweetIterator(ctx, OperationTweetQuotes, tweetID, maxTweetsNbr, func(tweetID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.fetchTweetQuotes(tweetID, maxTweetsNbr, cursor, &quoted)
+	})
+}
+
+// FetchTweetQuotes gets latest tweets quoting a given tweet, via search by quoted_tweet_id.
+// QuotedStatus of every tweet is set, the quoted tweet is fetched if no result includes it.
+// If it can't be fetched, tweets have only QuotedStatusID set.
+func (s *Scraper) FetchTweetQuotes(tweetID string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.fetchTweetQuotes(tweetID, maxTweetsNbr, cursor, &quotedTweet{})
+}
+
+// quotedTweet keeps the quoted tweet between pages.
+type quotedTweet struct {
+	tweet   *Tweet
+	fetched bool
+}
+
+func (s *Scraper) fetchTweetQuotes(tweetID string, maxTweetsNbr int, cursor string, quoted *quotedTweet) ([]*Tweet, string, error) {
+	query := SearchQuery{QuotedTweetID: tweetID}
+	raw, err := query.Build()
+	if err != nil {
+		return nil, "", err
+	}
+	tweets, nextCursor, err := s.FetchSearchTweetsWithOptions(raw, maxTweetsNbr, cursor, SearchOptions{Product: SearchProductLatest})
+	if err != nil {
+		return nil, "", err
+	}
+
+	// results can miss the quoted tweet, share it between them or get it once
+	missing := false
+	for _, tweet := range tweets {
+		if tweet.QuotedStatus == nil {
+			missing = true
+		} else if quoted.tweet == nil && tweet.QuotedStatus.ID == tweetID {
+			quoted.tweet = tweet.QuotedStatus
+		}
+	}
+	if missing && quoted.tweet == nil && !quoted.fetched {
+		quoted.fetched = true
+		// a deleted or protected quoted tweet doesn't make the quotes unavailable
+		if tweet, err := s.GetTweet(tweetID); err == nil {
+			quoted.tweet = tweet
+		}
+	}
+	for _, tweet := range tweets {
+		if tweet.QuotedStatus == nil {
+			tweet.QuotedStatus = quoted.tweet
+			tweet.QuotedStatusID = tweetID
+			tweet.IsQuoted = true
+		}
+	}
+	return tweets, nextCursor, nil
+}
@@ -0,0 +1,95 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// rawJSON holds the JSON an API object was decoded from. It is attached to the parsed
+// object only if Keep is set, which is done for scrapers with raw payloads enabled.
+type rawJSON struct {
+	Data json.RawMessage `json:"-"`
+	Keep bool            `json:"-"`
+}
+
+// payload returns a copy of the JSON, so the parsed object doesn't hold the whole response.
+func (raw *rawJSON) payload() json.RawMessage {
+	if !raw.Keep || len(raw.Data) == 0 {
+		return nil
+	}
+	return append(json.RawMessage(nil), raw.Data...)
+}
+
+var rawJSONType = reflect.TypeOf(rawJSON{})
+
+type (
+	resultAlias           result
+	userResultAlias       userResult
+	legacyUserResultAlias legacyUserResult
+)
+
+// keepRawPayloads sets Keep on every rawJSON reachable through exported fields of the
+// decoded response. Payloads nested in unexported fields are marked by their parent on parse.
+func keepRawPayloads(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			keepRawPayloads(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			keepRawPayloads(v.Index(i))
+		}
+	case reflect.Struct:
+		if v.Type() == rawJSONType {
+			if v.CanSet() {
+				v.FieldByName("Keep").SetBool(true)
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				keepRawPayloads(v.Field(i))
+			}
+		}
+	}
+}
+
+// UnmarshalJSON decodes the tweet result and keeps its JSON. The data is a part of
+// the response body, which is not reused, so it is copied only when attached.
+func (result *result) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*resultAlias)(result)); err != nil {
+		return err
+	}
+	result.Raw.Data = data
+	return nil
+}
+
+// keepRaw marks payloads of the author and related tweets.
+func (tweet *tweet) keepRaw() {
+	tweet.Core.UserResults.Result.Raw.Keep = true
+	if tweet.QuotedStatusResult.Result != nil {
+		tweet.QuotedStatusResult.Result.Raw.Keep = true
+	}
+	if tweet.Legacy.RetweetedStatusResult.Result != nil {
+		tweet.Legacy.RetweetedStatusResult.Result.Raw.Keep = true
+	}
+}
+
+// UnmarshalJSON decodes the user result and keeps its JSON.
+func (result *userResult) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*userResultAlias)(result)); err != nil {
+		return err
+	}
+	result.Raw.Data = data
+	return nil
+}
+
+// UnmarshalJSON decodes the user result and keeps its JSON.
+func (result *legacyUserResult) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*legacyUserResultAlias)(result)); err != nil {
+		return err
+	}
+	result.Raw.Data = data
+	return nil
+}
@@ -0,0 +1,110 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const rawConversationJSON = `{"data": {"threaded_conversation_with_injections_v2": {"instructions": [{
+	"type": "TimelineAddEntries",
+	"entries": [
+		{"entryId": "tweet-100", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "Tweet",
+			"rest_id": "100",
+			"unparsed_field": "focal",
+			"core": {"user_results": {"result": {"__typename": "User", "rest_id": "1", "legacy": {"screen_name": "x", "name": "X"}}}},
+			"quoted_status_result": {"result": {
+				"__typename": "Tweet",
+				"rest_id": "50",
+				"core": {"user_results": {"result": {"__typename": "User", "rest_id": "2", "legacy": {"screen_name": "y", "name": "Y"}}}},
+				"legacy": {"id_str": "50", "full_text": "quoted", "user_id_str": "2", "conversation_id_str": "50"}
+			}},
+			"legacy": {"id_str": "100", "full_text": "quoting", "user_id_str": "1", "conversation_id_str": "100", "is_quote_status": true, "quoted_status_id_str": "50"}
+		}}}}},
+		{"entryId": "tweet-101", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "TweetWithVisibilityResults",
+			"tweet": {
+				"rest_id": "101",
+				"core": {"user_results": {"result": {"__typename": "User", "rest_id": "1", "legacy": {"screen_name": "x", "name": "X"}}}},
+				"legacy": {"id_str": "101", "full_text": "reply", "user_id_str": "1", "conversation_id_str": "100"}
+			}
+		}}}}}
+	]
+}]}}}`
+
+// rawRestID returns rest_id of a raw payload, or of the tweet it wraps.
+func rawRestID(t *testing.T, raw json.RawMessage) string {
+	var payload struct {
+		RestID string `json:"rest_id"`
+		Tweet  struct {
+			RestID string `json:"rest_id"`
+		} `json:"tweet"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatalf("Raw payload %q is not valid JSON: %v", raw, err)
+	}
+	if payload.RestID == "" {
+		return payload.Tweet.RestID
+	}
+	return payload.RestID
+}
+
+func TestRawPayloadsKept(t *testing.T) {
+	var conversation threadedConversation
+	if err := json.Unmarshal([]byte(rawConversationJSON), &conversation); err != nil {
+		t.Fatal(err)
+	}
+	keepRawPayloads(reflect.ValueOf(&conversation))
+	tweets, _ := conversation.parse("100")
+	if len(tweets) != 2 {
+		t.Fatalf("Expected 2 tweets, got %d", len(tweets))
+	}
+
+	focal := tweets[0]
+	if id := rawRestID(t, focal.Raw); id != "100" {
+		t.Errorf("Expected Tweet.Raw of tweet 100, got %q", id)
+	}
+	if focal.Author == nil || rawRestID(t, focal.Author.Raw) != "1" {
+		t.Errorf("Expected Author.Raw of user 1, got %#v", focal.Author)
+	}
+	if focal.QuotedStatus == nil {
+		t.Fatal("Expected QuotedStatus is set")
+	}
+	if id := rawRestID(t, focal.QuotedStatus.Raw); id != "50" {
+		t.Errorf("Expected QuotedStatus.Raw of tweet 50, got %q", id)
+	}
+	if author := focal.QuotedStatus.Author; author == nil || rawRestID(t, author.Raw) != "2" {
+		t.Errorf("Expected QuotedStatus.Author.Raw of user 2, got %#v", author)
+	}
+	var unparsed struct {
+		UnparsedField string `json:"unparsed_field"`
+	}
+	if err := json.Unmarshal(focal.Raw, &unparsed); err != nil || unparsed.UnparsedField != "focal" {
+		t.Errorf("Expected fields which are not parsed in Tweet.Raw, got %q", focal.Raw)
+	}
+
+	// the visibility results wrapper is kept with the wrapped tweet
+	if id := rawRestID(t, tweets[1].Raw); id != "101" {
+		t.Errorf("Expected Tweet.Raw of tweet 101, got %q", id)
+	}
+	if tweets[1].Author == nil || len(tweets[1].Author.Raw) == 0 {
+		t.Error("Expected Author.Raw of a tweet with visibility results")
+	}
+}
+
+func TestRawPayloadsNotKept(t *testing.T) {
+	var conversation threadedConversation
+	if err := json.Unmarshal([]byte(rawConversationJSON), &conversation); err != nil {
+		t.Fatal(err)
+	}
+	tweets, _ := conversation.parse("100")
+	for _, tweet := range tweets {
+		if tweet.Raw != nil || tweet.Author.Raw != nil {
+			t.Errorf("Expected no raw payloads of tweet %s by default", tweet.ID)
+		}
+	}
+	if quoted := tweets[0].QuotedStatus; quoted == nil || quoted.Raw != nil {
+		t.Errorf("Expected quoted tweet without raw payload, got %#v", quoted)
+	}
+}
@@ -0,0 +1,67 @@
+package twitterscraper_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRawPayloads(t *testing.T) {
+	testScraper.WithRawPayloads(true)
+	defer testScraper.WithRawPayloads(false)
+
+	tweet, err := testScraper.GetTweet("1697304622749086011")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		RestID string `json:"rest_id"`
+	}
+	if err := json.Unmarshal(tweet.Raw, &raw); err != nil {
+		t.Fatalf("Tweet.Raw is not valid JSON: %v", err)
+	}
+	if raw.RestID != tweet.ID {
+		t.Errorf("Expected Tweet.Raw rest_id %s, got %q", tweet.ID, raw.RestID)
+	}
+	if tweet.Author == nil || len(tweet.Author.Raw) == 0 {
+		t.Error("Expected Author.Raw is set")
+	}
+
+	profile, err := testScraper.GetProfile("X")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(profile.Raw) {
+		t.Errorf("Expected Profile.Raw is valid JSON, got %q", profile.Raw)
+	}
+}
+
+func TestRawPayloadsDisabled(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1697304622749086011")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Raw != nil {
+		t.Error("Expected Tweet.Raw is not set by default")
+	}
+}
+
+func TestRawResponseHandler(t *testing.T) {
+	var paths []string
+	var body []byte
+	testScraper.WithRawResponseHandler(func(resp *http.Response, content []byte) {
+		paths = append(paths, resp.Request.URL.Path)
+		body = content
+	})
+	defer testScraper.WithRawResponseHandler(nil)
+
+	if _, err := testScraper.GetProfile("X"); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("Expected handler is called once, got %d calls", len(paths))
+	}
+	if !json.Valid(body) {
+		t.Error("Expected response body is valid JSON")
+	}
+}
@@ -0,0 +1,278 @@
+// Package render renders tweets as HTML, Markdown and plain text using entity indices.
+//
+// Rendering happens only when a function is called, so scraping doesn't pay for it.
+package render
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+// Options configures rendering.
+type Options struct {
+	// HashtagURL, CashtagURL and MentionURL are link templates
+	// where %s is replaced with the hashtag, cashtag or username.
+	HashtagURL string
+	CashtagURL string
+	MentionURL string
+	// LinkAttributes are added to every HTML link, like target="_blank".
+	LinkAttributes string
+	// EmbedMedia appends photos, videos and GIFs after the text.
+	EmbedMedia bool
+	// FullText keeps leading reply mentions which are hidden by default.
+	FullText bool
+}
+
+// DefaultOptions link to twitter.com and embed media.
+var DefaultOptions = Options{
+	HashtagURL: "https://twitter.com/hashtag/%s",
+	CashtagURL: "https://twitter.com/search?q=%%24%s",
+	MentionURL: "https://twitter.com/%s",
+	EmbedMedia: true,
+}
+
+type spanKind int
+
+const (
+	spanHashtag spanKind = iota
+	spanCashtag
+	spanMention
+	spanURL
+	spanMedia
+)
+
+// span is an entity of the text with offsets in runes.
+type span struct {
+	kind  spanKind
+	start int
+	end   int
+	text  string
+	url   string
+}
+
+// segment is either plain text or an entity.
+type segment struct {
+	text   string
+	entity *span
+}
+
+// segments splits the unescaped display text of a tweet into plain text and entities.
+func segments(tweet *twitterscraper.Tweet, options Options) []segment {
+	text := []rune(html.UnescapeString(tweet.Text))
+	start, end := 0, len(text)
+	if r := tweet.DisplayTextRange; r[1] > 0 && r[0] <= r[1] && r[1] <= len(text) {
+		end = r[1]
+		if !options.FullText {
+			start = r[0]
+		}
+	}
+
+	var spans []span
+	for _, hashtag := range tweet.Entities.Hashtags {
+		spans = append(spans, span{kind: spanHashtag, start: hashtag.Indices[0], end: hashtag.Indices[1], text: hashtag.Text})
+	}
+	for _, cashtag := range tweet.Entities.Cashtags {
+		spans = append(spans, span{kind: spanCashtag, start: cashtag.Indices[0], end: cashtag.Indices[1], text: cashtag.Text})
+	}
+	for _, mention := range tweet.Entities.Mentions {
+		spans = append(spans, span{kind: spanMention, start: mention.Indices[0], end: mention.Indices[1], text: mention.Username})
+	}
+	for _, url := range tweet.Entities.URLs {
+		expanded := url.ExpandedURL
+		if expanded == "" {
+			expanded = url.URL
+		}
+		display := url.DisplayURL
+		if display == "" {
+			display = expanded
+		}
+		spans = append(spans, span{kind: spanURL, start: url.Indices[0], end: url.Indices[1], text: display, url: expanded})
+	}
+	for _, media := range tweet.Media {
+		spans = append(spans, span{kind: spanMedia, start: media.Indices[0], end: media.Indices[1]})
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	var result []segment
+	pos := start
+	for i := range spans {
+		s := &spans[i]
+		// entities outside of the text or overlapping the previous one are skipped
+		if s.start < pos || s.end > end || s.start >= s.end {
+			continue
+		}
+		if s.start > pos {
+			result = append(result, segment{text: string(text[pos:s.start])})
+		}
+		result = append(result, segment{text: string(text[s.start:s.end]), entity: s})
+		pos = s.end
+	}
+	if pos < end {
+		result = append(result, segment{text: string(text[pos:end])})
+	}
+	return result
+}
+
+// videoURL returns the MP4 variant with the highest bitrate.
+func videoURL(media twitterscraper.TweetMedia) string {
+	url, bitrate := "", -1
+	for _, variant := range media.Variants {
+		if variant.ContentType == "video/mp4" && variant.Bitrate > bitrate {
+			url, bitrate = variant.URL, variant.Bitrate
+		}
+	}
+	return url
+}
+
+// withDefaults fills empty link templates from DefaultOptions.
+func withDefaults(options Options) Options {
+	if options.HashtagURL == "" {
+		options.HashtagURL = DefaultOptions.HashtagURL
+	}
+	if options.CashtagURL == "" {
+		options.CashtagURL = DefaultOptions.CashtagURL
+	}
+	if options.MentionURL == "" {
+		options.MentionURL = DefaultOptions.MentionURL
+	}
+	return options
+}
+
+func entityURL(template, value string) string {
+	return fmt.Sprintf(template, value)
+}
+
+// HTML renders a tweet as escaped HTML with DefaultOptions.
+func HTML(tweet *twitterscraper.Tweet) string {
+	return HTMLWithOptions(tweet, DefaultOptions)
+}
+
+// HTMLWithOptions renders a tweet as escaped HTML, links are built from entities
+// and new lines are replaced with <br>.
+func HTMLWithOptions(tweet *twitterscraper.Tweet, options Options) string {
+	options = withDefaults(options)
+	var sb strings.Builder
+	link := func(href, text string) {
+		sb.WriteString(`<a href="` + html.EscapeString(href) + `"`)
+		if options.LinkAttributes != "" {
+			sb.WriteString(" " + options.LinkAttributes)
+		}
+		sb.WriteString(">" + html.EscapeString(text) + "</a>")
+	}
+
+	for _, seg := range segments(tweet, options) {
+		if seg.entity == nil {
+			sb.WriteString(strings.Replace(html.EscapeString(seg.text), "\n", "<br>", -1))
+			continue
+		}
+		switch seg.entity.kind {
+		case spanHashtag:
+			link(entityURL(options.HashtagURL, seg.entity.text), seg.text)
+		case spanCashtag:
+			link(entityURL(options.CashtagURL, seg.entity.text), seg.text)
+		case spanMention:
+			link(entityURL(options.MentionURL, seg.entity.text), seg.text)
+		case spanURL:
+			link(seg.entity.url, seg.entity.text)
+		}
+	}
+
+	if options.EmbedMedia {
+		for _, media := range tweet.Media {
+			alt := html.EscapeString(media.AltText)
+			switch media.Type {
+			case "video", "animated_gif":
+				attributes := "controls"
+				if media.Type == "animated_gif" {
+					attributes = "autoplay loop muted playsinline"
+				}
+				sb.WriteString(fmt.Sprintf(`<br><video %s poster="%s" src="%s" title="%s"></video>`,
+					attributes, html.EscapeString(media.MediaURL), html.EscapeString(videoURL(media)), alt))
+			default:
+				sb.WriteString(fmt.Sprintf(`<br><img src="%s" alt="%s"/>`, html.EscapeString(media.MediaURL), alt))
+			}
+		}
+	}
+	return sb.String()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `#`, `\#`, `~`, `\~`, `|`, `\|`,
+)
+
+var markdownLinkEscaper = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`)
+
+// Markdown renders a tweet as Markdown with DefaultOptions.
+func Markdown(tweet *twitterscraper.Tweet) string {
+	return MarkdownWithOptions(tweet, DefaultOptions)
+}
+
+// MarkdownWithOptions renders a tweet as Markdown, text is escaped and entities become links.
+// Line breaks are kept as hard breaks.
+func MarkdownWithOptions(tweet *twitterscraper.Tweet, options Options) string {
+	options = withDefaults(options)
+	var sb strings.Builder
+	link := func(href, text string) {
+		sb.WriteString("[" + markdownLinkEscaper.Replace(text) + "](" + strings.Replace(href, ")", "%29", -1) + ")")
+	}
+
+	for _, seg := range segments(tweet, options) {
+		if seg.entity == nil {
+			sb.WriteString(strings.Replace(markdownEscaper.Replace(seg.text), "\n", "  \n", -1))
+			continue
+		}
+		switch seg.entity.kind {
+		case spanHashtag:
+			link(entityURL(options.HashtagURL, seg.entity.text), seg.text)
+		case spanCashtag:
+			link(entityURL(options.CashtagURL, seg.entity.text), seg.text)
+		case spanMention:
+			link(entityURL(options.MentionURL, seg.entity.text), seg.text)
+		case spanURL:
+			link(seg.entity.url, seg.entity.text)
+		}
+	}
+
+	if options.EmbedMedia {
+		for _, media := range tweet.Media {
+			image := "![" + markdownLinkEscaper.Replace(media.AltText) + "](" + media.MediaURL + ")"
+			if url := videoURL(media); url != "" {
+				image = "[" + image + "](" + url + ")"
+			}
+			sb.WriteString("\n\n" + image)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Text renders a tweet as plain text with t.co links expanded,
+// media links and leading reply mentions removed.
+func Text(tweet *twitterscraper.Tweet) string {
+	return TextWithOptions(tweet, DefaultOptions)
+}
+
+// TextWithOptions renders a tweet as plain text, only FullText of options is used.
+func TextWithOptions(tweet *twitterscraper.Tweet, options Options) string {
+	var sb strings.Builder
+	for _, seg := range segments(tweet, options) {
+		if seg.entity == nil {
+			sb.WriteString(seg.text)
+			continue
+		}
+		switch seg.entity.kind {
+		case spanURL:
+			sb.WriteString(seg.entity.url)
+		case spanMedia:
+		default:
+			sb.WriteString(seg.text)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
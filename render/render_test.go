@@ -0,0 +1,74 @@
+package render_test
+
+import (
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+	"github.com/imperatrona/twitter-scraper/render"
+)
+
+// sampleTweet is a reply with a non-Latin hashtag, a cashtag, an escaped ampersand,
+// a link and a trailing photo link.
+func sampleTweet() *twitterscraper.Tweet {
+	return &twitterscraper.Tweet{
+		Text:             "@nomadic_ua Tom &amp; Jerry #привет $TSLA <b> https://t.co/abcdefghij\nbye https://t.co/0123456789",
+		DisplayTextRange: [2]int{12, 69},
+		Entities: twitterscraper.Entities{
+			Mentions: []twitterscraper.MentionEntity{{Username: "nomadic_ua", Indices: [2]int{0, 11}}},
+			Hashtags: []twitterscraper.TextEntity{{Text: "привет", Indices: [2]int{24, 31}}},
+			Cashtags: []twitterscraper.TextEntity{{Text: "TSLA", Indices: [2]int{32, 37}}},
+			URLs: []twitterscraper.URLEntity{{
+				URL:         "https://t.co/abcdefghij",
+				DisplayURL:  "example.com/a?b=1",
+				ExpandedURL: "https://example.com/a?b=1&c=2",
+				Indices:     [2]int{42, 65},
+			}},
+		},
+		Media: []twitterscraper.TweetMedia{{
+			Type:     "photo",
+			MediaURL: "https://pbs.twimg.com/media/photo.jpg",
+			AltText:  `a "cat"`,
+			Indices:  [2]int{70, 93},
+		}},
+	}
+}
+
+func TestHTML(t *testing.T) {
+	expected := `Tom &amp; Jerry <a href="https://twitter.com/hashtag/привет">#привет</a> ` +
+		`<a href="https://twitter.com/search?q=%24TSLA">$TSLA</a> &lt;b&gt; ` +
+		`<a href="https://example.com/a?b=1&amp;c=2">example.com/a?b=1</a><br>bye` +
+		`<br><img src="https://pbs.twimg.com/media/photo.jpg" alt="a &#34;cat&#34;"/>`
+	if html := render.HTML(sampleTweet()); html != expected {
+		t.Errorf("Unexpected HTML:\n%s\nexpected:\n%s", html, expected)
+	}
+}
+
+func TestHTMLWithOptions(t *testing.T) {
+	options := render.Options{
+		MentionURL:     "https://example.com/@%s",
+		LinkAttributes: `target="_blank"`,
+		FullText:       true,
+	}
+	expected := `<a href="https://example.com/@nomadic_ua" target="_blank">@nomadic_ua</a> Tom`
+	html := render.HTMLWithOptions(sampleTweet(), options)
+	if len(html) < len(expected) || html[:len(expected)] != expected {
+		t.Errorf("Unexpected HTML:\n%s\nexpected prefix:\n%s", html, expected)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	expected := "Tom & Jerry [#привет](https://twitter.com/hashtag/привет) " +
+		"[$TSLA](https://twitter.com/search?q=%24TSLA) \\<b\\> " +
+		"[example.com/a?b=1](https://example.com/a?b=1&c=2)  \nbye" +
+		"\n\n![a \"cat\"](https://pbs.twimg.com/media/photo.jpg)"
+	if markdown := render.Markdown(sampleTweet()); markdown != expected {
+		t.Errorf("Unexpected Markdown:\n%s\nexpected:\n%s", markdown, expected)
+	}
+}
+
+func TestText(t *testing.T) {
+	expected := "Tom & Jerry #привет $TSLA <b> https://example.com/a?b=1&c=2\nbye"
+	if text := render.Text(sampleTweet()); text != expected {
+		t.Errorf("Unexpected text:\n%q\nexpected:\n%q", text, expected)
+	}
+}
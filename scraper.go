@@ -18,6 +18,8 @@ import (
 type Scraper struct {
 	bearerToken    string
 	client         *http.Client
+	consumerKey    string
+	consumerSecret string
 	delay          int64
 	guestToken     string
 	guestCreatedAt time.Time
@@ -27,9 +29,12 @@ type Scraper struct {
 	oAuthToken     string
 	oAuthSecret    string
 	proxy          string
+	rawPayloads    bool
+	rawResponse    func(resp *http.Response, body []byte)
 	userAgent      string
 	searchMode     SearchMode
-	wg             sync.WaitGroup
+	pace           sync.Mutex
+	guest          sync.Mutex // guards guestToken and guestCreatedAt
 }
 
 // SearchMode type
@@ -48,6 +53,42 @@ const (
 	SearchUsers
 )
 
+// SearchProduct is a tab of search results.
+type SearchProduct string
+
+const (
+	SearchProductTop    SearchProduct = "Top"
+	SearchProductLatest SearchProduct = "Latest"
+	SearchProductPhotos SearchProduct = "Photos"
+	SearchProductVideos SearchProduct = "Videos"
+	SearchProductPeople SearchProduct = "People"
+	SearchProductLists  SearchProduct = "Lists"
+)
+
+// SearchOptions are options of a single search call.
+type SearchOptions struct {
+	// Product of results, the search mode of the scraper by default.
+	Product SearchProduct
+	// PageSize is the number of results requested per page, up to 50.
+	PageSize int
+	// QuerySource tells how the query was entered, "typed_query" by default.
+	QuerySource string
+}
+
+func (mode SearchMode) product() SearchProduct {
+	switch mode {
+	case SearchLatest:
+		return SearchProductLatest
+	case SearchPhotos:
+		return SearchProductPhotos
+	case SearchVideos:
+		return SearchProductVideos
+	case SearchUsers:
+		return SearchProductPeople
+	}
+	return SearchProductTop
+}
+
 // default http client timeout
 const DefaultClientTimeout = 10 * time.Second
 const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36"
@@ -56,8 +97,10 @@ const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleW
 func New() *Scraper {
 	jar, _ := cookiejar.New(nil)
 	return &Scraper{
-		bearerToken: bearerToken,
-		userAgent:   DefaultUserAgent,
+		bearerToken:    bearerToken,
+		consumerKey:    appConsumerKey,
+		consumerSecret: appConsumerSecret,
+		userAgent:      DefaultUserAgent,
 		client: &http.Client{
 			Jar:     jar,
 			Timeout: DefaultClientTimeout,
@@ -67,11 +110,15 @@ func New() *Scraper {
 
 func (s *Scraper) setBearerToken(token string) {
 	s.bearerToken = token
+	s.guest.Lock()
 	s.guestToken = ""
+	s.guest.Unlock()
 }
 
 // IsGuestToken check if guest token not empty
 func (s *Scraper) IsGuestToken() bool {
+	s.guest.Lock()
+	defer s.guest.Unlock()
 	return s.guestToken != ""
 }
 
@@ -93,6 +140,21 @@ func (s *Scraper) WithReplies(b bool) *Scraper {
 	return s
 }
 
+// WithRawPayloads enable/disable attaching the original JSON of tweet and user results
+// to Tweet.Raw and Profile.Raw. Only GraphQL responses have them, tweets and profiles
+// of API v1 timelines, used with open accounts, never get Raw.
+func (s *Scraper) WithRawPayloads(b bool) *Scraper {
+	s.rawPayloads = b
+	return s
+}
+
+// WithRawResponseHandler set a function called with every response and its body received
+// by RequestAPI, before it is decoded. The body must not be modified.
+func (s *Scraper) WithRawResponseHandler(handler func(resp *http.Response, body []byte)) *Scraper {
+	s.rawResponse = handler
+	return s
+}
+
 // client timeout
 func (s *Scraper) WithClientTimeout(timeout time.Duration) *Scraper {
 	s.client.Timeout = timeout
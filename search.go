@@ -3,8 +3,11 @@ package twitterscraper
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const searchURL = "https://twitter.com/i/api/graphql/nK1dw4oV3k4w5TdtcAdSww/SearchTimeline"
@@ -36,20 +39,8 @@ func (timeline *searchTimeline) parseTweets() ([]*Tweet, string) {
 			}
 			for _, entry := range instruction.Entries {
 				if entry.Content.ItemContent.TweetDisplayType == "Tweet" {
-					var legacy *legacyTweet = &entry.Content.ItemContent.TweetResults.Result.Legacy
-					var user *legacyUser = &entry.Content.ItemContent.TweetResults.Result.Core.UserResults.Result.Legacy
-					if entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
-						legacy = &entry.Content.ItemContent.TweetResults.Result.Tweet.Legacy
-						user = &entry.Content.ItemContent.TweetResults.Result.Tweet.Core.UserResults.Result.Legacy
-					}
-					if tweet := parseLegacyTweet(user, legacy); tweet != nil {
-						var views = entry.Content.ItemContent.TweetResults.Result.Views.Count
-						if entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
-							views = entry.Content.ItemContent.TweetResults.Result.Tweet.Views.Count
-						}
-						if tweet.Views == 0 && views != "" {
-							tweet.Views, _ = strconv.Atoi(views)
-						}
+					if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+						tweet.EntryType = parseEntryType(entry.EntryID, entry.Content.ItemContent.PromotedMetadata != nil)
 						tweets = append(tweets, tweet)
 					}
 				} else if entry.Content.CursorType == "Bottom" {
@@ -87,25 +78,103 @@ func (timeline *searchTimeline) parseUsers() ([]*Profile, string) {
 	return profiles, cursor
 }
 
+func (timeline *searchTimeline) parseLists() ([]*List, string) {
+	lists := make([]*List, 0)
+	cursor := ""
+	for _, instruction := range timeline.Data.SearchByRawQuery.SearchTimeline.Timeline.Instructions {
+		if instruction.Type == "TimelineAddEntries" || instruction.Type == "TimelineReplaceEntry" {
+			if instruction.Entry.Content.CursorType == "Bottom" {
+				cursor = instruction.Entry.Content.Value
+				continue
+			}
+			for _, entry := range instruction.Entries {
+				if entry.Content.CursorType == "Bottom" {
+					cursor = entry.Content.Value
+					continue
+				}
+				if entry.Content.ItemContent.List != nil {
+					lists = append(lists, entry.Content.ItemContent.List.parse())
+				}
+				for _, item := range entry.Content.Items {
+					if item.Item.ItemContent.List != nil {
+						lists = append(lists, item.Item.ItemContent.List.parse())
+					}
+				}
+			}
+		}
+	}
+	return lists, cursor
+}
+
 // SearchTweets returns channel with tweets for a given search query
 func (s *Scraper) SearchTweets(ctx context.Context, query string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, query, maxTweetsNbr, s.FetchSearchTweets)
+	return s.getTweetTimeline(ctx, OperationSearchTweets, query, maxTweetsNbr, s.FetchSearchTweets)
 }
 
 // SearchProfiles returns channel with profiles for a given search query
 func (s *Scraper) SearchProfiles(ctx context.Context, query string, maxProfilesNbr int) <-chan *ProfileResult {
-	return getUserTimeline(ctx, query, maxProfilesNbr, s.FetchSearchProfiles)
+	return s.getUserTimeline(ctx, OperationSearchProfiles, query, maxProfilesNbr, s.FetchSearchProfiles)
+}
+
+// IterSearchTweets returns iterator over tweets for a given search query
+func (s *Scraper) IterSearchTweets(ctx context.Context, query string, maxTweetsNbr int) *TweetIterator {
+	return s.IterSearchTweetsWithOptions(ctx, query, maxTweetsNbr, SearchOptions{})
+}
+
+// SearchTweetsWithOptions returns channel with tweets for a given search query with per call options
+func (s *Scraper) SearchTweetsWithOptions(ctx context.Context, query string, maxTweetsNbr int, options SearchOptions) <-chan *TweetResult {
+	return s.IterSearchTweetsWithOptions(ctx, query, maxTweetsNbr, options).Chan()
+}
+
+// IterSearchTweetsWithOptions returns iterator over tweets for a given search query with per call options
+func (s *Scraper) IterSearchTweetsWithOptions(ctx context.Context, query string, maxTweetsNbr int, options SearchOptions) *TweetIterator {
+	if options.Product == "" {
+		options.Product = s.searchMode.product()
+	}
+	it := s.newTweetIterator(ctx, OperationSearchTweets, query, maxTweetsNbr, func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+		return s.FetchSearchTweetsWithOptions(query, maxTweetsNbr, cursor, options)
+	})
+	// results and page boundaries differ by product and page size
+	it.params = url.Values{"product": {string(options.Product)}}
+	if options.PageSize > 0 {
+		it.params.Set("page_size", strconv.Itoa(options.PageSize))
+	}
+	return it
+}
+
+// SearchLists returns channel with lists for a given search query
+func (s *Scraper) SearchLists(ctx context.Context, query string, maxListsNbr int) <-chan *ListResult {
+	return s.IterSearchLists(ctx, query, maxListsNbr).Chan()
+}
+
+// IterSearchLists returns iterator over lists for a given search query
+func (s *Scraper) IterSearchLists(ctx context.Context, query string, maxListsNbr int) *ListIterator {
+	return s.newListIterator(ctx, OperationSearchLists, query, maxListsNbr, s.FetchSearchLists)
+}
+
+// IterSearchProfiles returns iterator over profiles for a given search query
+func (s *Scraper) IterSearchProfiles(ctx context.Context, query string, maxProfilesNbr int) *ProfileIterator {
+	return s.newProfileIterator(ctx, OperationSearchProfiles, query, maxProfilesNbr, s.FetchSearchProfiles)
 }
 
 // getSearchTimeline gets results for a given search query, via the Twitter frontend API
-func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*searchTimeline, error) {
+func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string, options SearchOptions) (*searchTimeline, error) {
 	if !s.isLogged {
 		return nil, errors.New("scraper is not logged in for search")
 	}
 
+	if options.PageSize > 0 {
+		maxNbr = options.PageSize
+	}
 	if maxNbr > 50 {
 		maxNbr = 50
 	}
+	if options.Product == "" {
+		options.Product = s.searchMode.product()
+	}
+	if options.QuerySource == "" {
+		options.QuerySource = "typed_query"
+	}
 
 	req, err := s.newRequest("GET", searchURL)
 	if err != nil {
@@ -115,8 +184,8 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 	variables := map[string]interface{}{
 		"rawQuery":    query,
 		"count":       maxNbr,
-		"querySource": "typed_query",
-		"product":     "Top",
+		"querySource": options.QuerySource,
+		"product":     string(options.Product),
 	}
 
 	features := map[string]interface{}{
@@ -149,16 +218,6 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 	if cursor != "" {
 		variables["cursor"] = cursor
 	}
-	switch s.searchMode {
-	case SearchLatest:
-		variables["product"] = "Latest"
-	case SearchPhotos:
-		variables["product"] = "Photos"
-	case SearchVideos:
-		variables["product"] = "Videos"
-	case SearchUsers:
-		variables["product"] = "People"
-	}
 
 	q := url.Values{}
 	q.Set("variables", mapToJSONString(variables))
@@ -176,7 +235,12 @@ func (s *Scraper) getSearchTimeline(query string, maxNbr int, cursor string) (*s
 
 // FetchSearchTweets gets tweets for a given search query, via the Twitter frontend API
 func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, cursor)
+	return s.FetchSearchTweetsWithOptions(query, maxTweetsNbr, cursor, SearchOptions{})
+}
+
+// FetchSearchTweetsWithOptions gets tweets for a given search query with per call options.
+func (s *Scraper) FetchSearchTweetsWithOptions(query string, maxTweetsNbr int, cursor string, options SearchOptions) ([]*Tweet, string, error) {
+	timeline, err := s.getSearchTimeline(query, maxTweetsNbr, cursor, options)
 	if err != nil {
 		return nil, "", err
 	}
@@ -184,12 +248,163 @@ func (s *Scraper) FetchSearchTweets(query string, maxTweetsNbr int, cursor strin
 	return tweets, nextCursor, nil
 }
 
-// FetchSearchProfiles gets users for a given search query, via the Twitter frontend API
+// fetchLatestSearchTweets gets tweets for a given search query ordered from newest to oldest.
+func (s *Scraper) fetchLatestSearchTweets(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	return s.FetchSearchTweetsWithOptions(query, maxTweetsNbr, cursor, SearchOptions{Product: SearchProductLatest})
+}
+
+// FetchSearchProfiles gets users for a given search query, via the Twitter frontend API.
+// It always searches people regardless of the search mode.
 func (s *Scraper) FetchSearchProfiles(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error) {
-	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, cursor)
+	timeline, err := s.getSearchTimeline(query, maxProfilesNbr, cursor, SearchOptions{Product: SearchProductPeople})
 	if err != nil {
 		return nil, "", err
 	}
 	users, nextCursor := timeline.parseUsers()
 	return users, nextCursor, nil
 }
+
+// FetchSearchLists gets lists for a given search query, via the Twitter frontend API
+func (s *Scraper) FetchSearchLists(query string, maxListsNbr int, cursor string) ([]*List, string, error) {
+	timeline, err := s.getSearchTimeline(query, maxListsNbr, cursor, SearchOptions{Product: SearchProductLists})
+	if err != nil {
+		return nil, "", err
+	}
+	lists, nextCursor := timeline.parseLists()
+	return lists, nextCursor, nil
+}
+
+type typeaheadResponse struct {
+	Users  []legacyUser `json:"users"`
+	Topics []struct {
+		Topic   string `json:"topic"`
+		TopicID string `json:"topic_id"`
+	} `json:"topics"`
+	Hashtags []struct {
+		Hashtag string `json:"hashtag"`
+	} `json:"hashtags"`
+}
+
+// SearchTypeahead gets autocomplete suggestions for a search prefix.
+func (s *Scraper) SearchTypeahead(prefix string) (*Typeahead, error) {
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/1.1/search/typeahead.json")
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("q", prefix)
+	q.Set("src", "search_box")
+	// only result types which are parsed are requested
+	q.Set("result_type", "users,topics")
+	req.URL.RawQuery = q.Encode()
+
+	var response typeaheadResponse
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	typeahead := &Typeahead{}
+	for _, user := range response.Users {
+		typeahead.Users = append(typeahead.Users, parseProfile(user))
+	}
+	for _, hashtag := range response.Hashtags {
+		typeahead.Hashtags = append(typeahead.Hashtags, strings.TrimPrefix(hashtag.Hashtag, "#"))
+	}
+	for _, topic := range response.Topics {
+		switch {
+		case strings.HasPrefix(topic.Topic, "#"):
+			typeahead.Hashtags = append(typeahead.Hashtags, strings.TrimPrefix(topic.Topic, "#"))
+		case topic.TopicID != "":
+			typeahead.Topics = append(typeahead.Topics, TypeaheadTopic{ID: topic.TopicID, Name: topic.Topic})
+		default:
+			typeahead.Queries = append(typeahead.Queries, topic.Topic)
+		}
+	}
+	return typeahead, nil
+}
+
+type savedSearch struct {
+	IDStr     string `json:"id_str"`
+	Name      string `json:"name"`
+	Query     string `json:"query"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (search *savedSearch) parse() SavedSearch {
+	saved := SavedSearch{
+		ID:    search.IDStr,
+		Name:  search.Name,
+		Query: search.Query,
+	}
+	if tm, err := time.Parse(time.RubyDate, search.CreatedAt); err == nil {
+		saved.CreatedAt = tm.UTC()
+	}
+	return saved
+}
+
+// GetSavedSearches returns saved searches of the logged in user.
+func (s *Scraper) GetSavedSearches() ([]SavedSearch, error) {
+	if !s.isLogged {
+		return nil, errors.New("scraper is not logged in")
+	}
+
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/1.1/saved_searches/list.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var response []savedSearch
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	searches := make([]SavedSearch, 0, len(response))
+	for _, search := range response {
+		searches = append(searches, search.parse())
+	}
+	return searches, nil
+}
+
+// CreateSavedSearch saves a search query for the logged in user.
+func (s *Scraper) CreateSavedSearch(query string) (SavedSearch, error) {
+	if !s.isLogged {
+		return SavedSearch{}, errors.New("scraper is not logged in")
+	}
+
+	req, err := s.newRequest("POST", "https://twitter.com/i/api/1.1/saved_searches/create.json")
+	if err != nil {
+		return SavedSearch{}, err
+	}
+
+	q := req.URL.Query()
+	q.Set("query", query)
+	req.URL.RawQuery = q.Encode()
+
+	var response savedSearch
+	err = s.RequestAPI(req, &response)
+	if err != nil {
+		return SavedSearch{}, err
+	}
+	return response.parse(), nil
+}
+
+// DeleteSavedSearch deletes a saved search by ID.
+func (s *Scraper) DeleteSavedSearch(id string) error {
+	if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+		return fmt.Errorf("invalid saved search id %q", id)
+	}
+	if !s.isLogged {
+		return errors.New("scraper is not logged in")
+	}
+
+	req, err := s.newRequest("POST", "https://twitter.com/i/api/1.1/saved_searches/destroy/"+id+".json")
+	if err != nil {
+		return err
+	}
+
+	var response savedSearch
+	return s.RequestAPI(req, &response)
+}
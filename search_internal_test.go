@@ -0,0 +1,61 @@
+package twitterscraper
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const typeaheadJSON = `{
+	"num_results": 5,
+	"users": [{"id_str": "44196397", "screen_name": "elonmusk", "name": "Elon Musk"}],
+	"topics": [
+		{"topic": "#elonmusk", "topic_id": ""},
+		{"topic": "Elon Musk", "topic_id": "1022286932394864640"},
+		{"topic": "elon musk news", "topic_id": ""}
+	],
+	"hashtags": [{"hashtag": "#elon"}]
+}`
+
+func TestSearchTypeaheadRequest(t *testing.T) {
+	var query url.Values
+	scraper := New()
+	scraper.client.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"guest_token": "1"}`
+		if strings.HasSuffix(req.URL.Path, "/search/typeahead.json") {
+			query = req.URL.Query()
+			body = typeaheadJSON
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+
+	typeahead, err := scraper.SearchTypeahead("elon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// result types which are not parsed must not be requested
+	if resultType := query.Get("result_type"); resultType != "users,topics" {
+		t.Errorf("Expected only users and topics are requested, got %q", resultType)
+	}
+
+	if len(typeahead.Users) != 1 || typeahead.Users[0].Username != "elonmusk" {
+		t.Errorf("Expected user elonmusk, got %v", typeahead.Users)
+	}
+	if expected := []string{"elon", "elonmusk"}; !reflect.DeepEqual(typeahead.Hashtags, expected) {
+		t.Errorf("Expected hashtags %v, got %v", expected, typeahead.Hashtags)
+	}
+	if expected := []TypeaheadTopic{{ID: "1022286932394864640", Name: "Elon Musk"}}; !reflect.DeepEqual(typeahead.Topics, expected) {
+		t.Errorf("Expected topics %v, got %v", expected, typeahead.Topics)
+	}
+	if expected := []string{"elon musk news"}; !reflect.DeepEqual(typeahead.Queries, expected) {
+		t.Errorf("Expected queries %v, got %v", expected, typeahead.Queries)
+	}
+}
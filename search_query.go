@@ -0,0 +1,680 @@
+package twitterscraper
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+// SearchFilter is a value of the filter: search operator.
+type SearchFilter string
+
+// Search filters.
+const (
+	FilterMedia        SearchFilter = "media"
+	FilterImages       SearchFilter = "images"
+	FilterVideos       SearchFilter = "native_video"
+	FilterLinks        SearchFilter = "links"
+	FilterReplies      SearchFilter = "replies"
+	FilterRetweets     SearchFilter = "nativeretweets"
+	FilterQuotes       SearchFilter = "quote"
+	FilterVerified     SearchFilter = "verified"
+	FilterBlueVerified SearchFilter = "blue_verified"
+	FilterHashtags     SearchFilter = "hashtags"
+	FilterSafe         SearchFilter = "safe"
+)
+
+var searchFilters = map[SearchFilter]bool{
+	FilterMedia:        true,
+	FilterImages:       true,
+	FilterVideos:       true,
+	FilterLinks:        true,
+	FilterReplies:      true,
+	FilterRetweets:     true,
+	FilterQuotes:       true,
+	FilterVerified:     true,
+	FilterBlueVerified: true,
+	FilterHashtags:     true,
+	FilterSafe:         true,
+}
+
+// GeoCode limits search to tweets posted within Radius ("10km" or "5mi") of a point.
+type GeoCode struct {
+	Latitude  float64
+	Longitude float64
+	Radius    string
+}
+
+// SearchQuery builds a search query from advanced operators.
+// String returns the raw query and ParseSearchQuery converts a raw query back.
+//
+//	query := twitterscraper.SearchQuery{
+//		From:           []string{"x"},
+//		Phrases:        []string{"open source"},
+//		MinFaves:       100,
+//		ExcludeFilters: []twitterscraper.SearchFilter{twitterscraper.FilterReplies},
+//	}
+//	raw, err := query.Build()
+type SearchQuery struct {
+	// Words must all be present. A word with a colon is quoted so that
+	// it is not read as an operator, use Raw for operators.
+	Words []string
+	// Phrases must be present exactly as written.
+	Phrases []string
+	// AnyOf are groups of words or phrases where at least one of each group must be present.
+	AnyOf [][]string
+	// Exclude are words or phrases which must not be present.
+	Exclude  []string
+	Hashtags []string
+	// From, To and Mentions are usernames, several of them are joined with OR.
+	From     []string
+	To       []string
+	Mentions []string
+	// Since and Until bound the creation time, whole days are written as dates.
+	Since time.Time
+	Until time.Time
+	// SinceID and MaxID bound tweet IDs.
+	SinceID        string
+	MaxID          string
+	MinFaves       int
+	MinRetweets    int
+	MinReplies     int
+	Filters        []SearchFilter
+	ExcludeFilters []SearchFilter
+	Lang           string
+	GeoCode        *GeoCode
+	// Near is a place name, Within is the radius around it ("15mi").
+	Near           string
+	Within         string
+	ConversationID string
+	QuotedTweetID  string
+	URL            string
+	// Raw are terms written as is, the parser keeps operators it doesn't know here.
+	Raw []string
+}
+
+var (
+	reSearchUsername = regexp.MustCompile(`^[A-Za-z0-9_]{1,15}$`)
+	reSearchLang     = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]+)?$`)
+	reSearchRadius   = regexp.MustCompile(`^\d+(\.\d+)?(km|mi)$`)
+	reSearchID       = regexp.MustCompile(`^\d+$`)
+)
+
+const searchTimeLayout = "2006-01-02_15:04:05_UTC"
+
+// String returns the raw search query without validating it.
+func (q *SearchQuery) String() string {
+	var terms []string
+	for _, word := range q.Words {
+		terms = append(terms, quoteSearchTerm(word))
+	}
+	for _, phrase := range q.Phrases {
+		terms = append(terms, `"`+phrase+`"`)
+	}
+	for _, group := range q.AnyOf {
+		var any []string
+		for _, word := range group {
+			any = append(any, quoteSearchTerm(word))
+		}
+		terms = append(terms, joinSearchGroup(any))
+	}
+	for _, hashtag := range q.Hashtags {
+		terms = append(terms, "#"+strings.TrimPrefix(hashtag, "#"))
+	}
+	terms = appendUsernames(terms, "@", q.Mentions)
+	terms = appendUsernames(terms, "from:", q.From)
+	terms = appendUsernames(terms, "to:", q.To)
+	for _, word := range q.Exclude {
+		terms = append(terms, "-"+quoteSearchTerm(word))
+	}
+	for _, filter := range q.Filters {
+		terms = append(terms, "filter:"+string(filter))
+	}
+	for _, filter := range q.ExcludeFilters {
+		terms = append(terms, "-filter:"+string(filter))
+	}
+	if q.Lang != "" {
+		terms = append(terms, "lang:"+q.Lang)
+	}
+	if q.MinFaves > 0 {
+		terms = append(terms, "min_faves:"+strconv.Itoa(q.MinFaves))
+	}
+	if q.MinRetweets > 0 {
+		terms = append(terms, "min_retweets:"+strconv.Itoa(q.MinRetweets))
+	}
+	if q.MinReplies > 0 {
+		terms = append(terms, "min_replies:"+strconv.Itoa(q.MinReplies))
+	}
+	if !q.Since.IsZero() {
+		terms = append(terms, formatSearchTime("since", q.Since))
+	}
+	if !q.Until.IsZero() {
+		terms = append(terms, formatSearchTime("until", q.Until))
+	}
+	if q.SinceID != "" {
+		terms = append(terms, "since_id:"+q.SinceID)
+	}
+	if q.MaxID != "" {
+		terms = append(terms, "max_id:"+q.MaxID)
+	}
+	if q.ConversationID != "" {
+		terms = append(terms, "conversation_id:"+q.ConversationID)
+	}
+	if q.QuotedTweetID != "" {
+		terms = append(terms, "quoted_tweet_id:"+q.QuotedTweetID)
+	}
+	if q.URL != "" {
+		terms = append(terms, "url:"+quoteSearchTerm(q.URL))
+	}
+	if q.GeoCode != nil {
+		terms = append(terms, fmt.Sprintf("geocode:%s,%s,%s",
+			strconv.FormatFloat(q.GeoCode.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(q.GeoCode.Longitude, 'f', -1, 64),
+			q.GeoCode.Radius))
+	}
+	if q.Near != "" {
+		terms = append(terms, "near:"+quoteSearchTerm(q.Near))
+	}
+	if q.Within != "" {
+		terms = append(terms, "within:"+q.Within)
+	}
+	terms = append(terms, q.Raw...)
+	return strings.Join(terms, " ")
+}
+
+// Validate checks that every operator has a value Twitter accepts.
+func (q *SearchQuery) Validate() error {
+	if q.String() == "" {
+		return errors.New("search query is empty")
+	}
+	for _, word := range q.Words {
+		if err := validateSearchWord(word); err != nil {
+			return err
+		}
+		if strings.ContainsAny(word, " \t\n") {
+			return fmt.Errorf("search word %q contains spaces, use Phrases", word)
+		}
+	}
+	for _, phrase := range q.Phrases {
+		if err := validateSearchWord(phrase); err != nil {
+			return err
+		}
+	}
+	for _, group := range q.AnyOf {
+		if len(group) == 0 {
+			return errors.New("search AnyOf group is empty")
+		}
+		for _, word := range group {
+			if err := validateSearchWord(word); err != nil {
+				return err
+			}
+		}
+	}
+	for _, word := range q.Exclude {
+		if err := validateSearchWord(word); err != nil {
+			return err
+		}
+	}
+	for _, hashtag := range q.Hashtags {
+		if tag := strings.TrimPrefix(hashtag, "#"); tag == "" || strings.ContainsAny(tag, " \t\n#\"") {
+			return fmt.Errorf("invalid search hashtag %q", hashtag)
+		}
+	}
+	for _, usernames := range [][]string{q.From, q.To, q.Mentions} {
+		for _, username := range usernames {
+			if !reSearchUsername.MatchString(strings.TrimPrefix(username, "@")) {
+				return fmt.Errorf("invalid search username %q", username)
+			}
+		}
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return errors.New("search since must be before until")
+	}
+	for name, id := range map[string]string{
+		"since_id":        q.SinceID,
+		"max_id":          q.MaxID,
+		"conversation_id": q.ConversationID,
+		"quoted_tweet_id": q.QuotedTweetID,
+	} {
+		if id != "" && !reSearchID.MatchString(id) {
+			return fmt.Errorf("invalid search %s %q", name, id)
+		}
+	}
+	if q.SinceID != "" && q.MaxID != "" && snowflake.Compare(q.SinceID, q.MaxID) >= 0 {
+		return errors.New("search since_id must be lower than max_id")
+	}
+	if q.MinFaves < 0 || q.MinRetweets < 0 || q.MinReplies < 0 {
+		return errors.New("search minimum counts must not be negative")
+	}
+	excluded := make(map[SearchFilter]bool)
+	for _, filter := range q.ExcludeFilters {
+		if !searchFilters[filter] {
+			return fmt.Errorf("unknown search filter %q", filter)
+		}
+		excluded[filter] = true
+	}
+	for _, filter := range q.Filters {
+		if !searchFilters[filter] {
+			return fmt.Errorf("unknown search filter %q", filter)
+		}
+		if excluded[filter] {
+			return fmt.Errorf("search filter %q is both required and excluded", filter)
+		}
+	}
+	if q.Lang != "" && !reSearchLang.MatchString(q.Lang) {
+		return fmt.Errorf("invalid search lang %q", q.Lang)
+	}
+	if q.GeoCode != nil {
+		if q.GeoCode.Latitude < -90 || q.GeoCode.Latitude > 90 || q.GeoCode.Longitude < -180 || q.GeoCode.Longitude > 180 {
+			return errors.New("search geocode is out of range")
+		}
+		if !reSearchRadius.MatchString(q.GeoCode.Radius) {
+			return fmt.Errorf("invalid search geocode radius %q", q.GeoCode.Radius)
+		}
+	}
+	if q.Near != "" {
+		if err := validateSearchWord(q.Near); err != nil {
+			return err
+		}
+	}
+	if q.Within != "" {
+		if q.Near == "" {
+			return errors.New("search within requires near")
+		}
+		if !reSearchRadius.MatchString(q.Within) {
+			return fmt.Errorf("invalid search within radius %q", q.Within)
+		}
+	}
+	if q.URL != "" {
+		if err := validateSearchWord(q.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetIDRange sets SinceID and MaxID to tweets created at or after since and before until
+// with millisecond precision, since: and until: operators have only seconds.
+// A zero time leaves its bound unchanged.
+func (q *SearchQuery) SetIDRange(since, until time.Time) {
+	if !since.IsZero() {
+		q.SinceID = snowflake.MaxID(since.Add(-time.Millisecond))
+	}
+	if !until.IsZero() {
+		q.MaxID = snowflake.MaxID(until.Add(-time.Millisecond))
+	}
+}
+
+// Build validates the query and returns it as raw string.
+func (q *SearchQuery) Build() (string, error) {
+	if err := q.Validate(); err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
+// ParseSearchQuery parses a raw search query. Operators which SearchQuery
+// doesn't model are kept in Raw, so String returns an equivalent query.
+func ParseSearchQuery(raw string) (*SearchQuery, error) {
+	tokens, err := tokenizeSearchQuery(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &SearchQuery{}
+	for i := 0; i < len(tokens); {
+		var group []string
+		grouped := false
+		for {
+			if i >= len(tokens) {
+				return nil, errors.New("search query ends with OR")
+			}
+			terms, next, err := parseSearchUnit(tokens, i)
+			if err != nil {
+				return nil, err
+			}
+			grouped = grouped || len(terms) > 1 || tokens[i] == "("
+			group = append(group, terms...)
+			i = next
+			if i < len(tokens) && tokens[i] == "OR" {
+				grouped = true
+				i++
+				continue
+			}
+			break
+		}
+		if !grouped {
+			if err := q.applyTerm(group[0]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		q.applyGroup(group)
+	}
+	return q, nil
+}
+
+// parseSearchUnit returns terms of a single term or a parenthesized OR group starting at i.
+// Groups which are not a plain OR of terms are returned as one term written as is.
+func parseSearchUnit(tokens []string, i int) ([]string, int, error) {
+	switch tokens[i] {
+	case "OR":
+		return nil, 0, errors.New("search query has OR without a term before it")
+	case ")":
+		return nil, 0, errors.New("search query has unbalanced parentheses")
+	case "(", "-(":
+	default:
+		return []string{tokens[i]}, i + 1, nil
+	}
+
+	end := -1
+	for j := i + 1; j < len(tokens) && end < 0; j++ {
+		switch tokens[j] {
+		case "(", "-(":
+			return nil, 0, errors.New("nested search groups are not supported")
+		case ")":
+			end = j
+		}
+	}
+	if end < 0 {
+		return nil, 0, errors.New("search query has unbalanced parentheses")
+	}
+
+	inner := tokens[i+1 : end]
+	alternation := tokens[i] == "(" && len(inner)%2 == 1
+	for j, token := range inner {
+		if (token == "OR") != (j%2 == 1) {
+			alternation = false
+		}
+	}
+	if !alternation {
+		return []string{tokens[i] + strings.Join(inner, " ") + ")"}, end + 1, nil
+	}
+	var terms []string
+	for j := 0; j < len(inner); j += 2 {
+		terms = append(terms, inner[j])
+	}
+	return terms, end + 1, nil
+}
+
+// applyGroup sets terms joined with OR. Groups of usernames of one kind and
+// groups of words are modeled, anything else is kept in Raw.
+func (q *SearchQuery) applyGroup(terms []string) {
+	kind := searchTermKind(terms[0])
+	for _, term := range terms[1:] {
+		if searchTermKind(term) != kind {
+			kind = ""
+		}
+	}
+
+	var usernames *[]string
+	switch kind {
+	case "from:":
+		usernames = &q.From
+	case "to:":
+		usernames = &q.To
+	case "@":
+		usernames = &q.Mentions
+	case "word":
+		var group []string
+		for _, term := range terms {
+			group = append(group, unquoteSearchTerm(term))
+		}
+		q.AnyOf = append(q.AnyOf, group)
+		return
+	}
+	// usernames of one kind are always joined with OR, so only one group fits the field
+	if usernames == nil || len(*usernames) > 0 {
+		q.Raw = append(q.Raw, joinSearchGroup(terms))
+		return
+	}
+	for _, term := range terms {
+		*usernames = append(*usernames, strings.TrimPrefix(term, kind))
+	}
+}
+
+func searchTermKind(term string) string {
+	switch {
+	case strings.HasPrefix(term, "from:"):
+		return "from:"
+	case strings.HasPrefix(term, "to:"):
+		return "to:"
+	case strings.HasPrefix(term, "@") && len(term) > 1:
+		return "@"
+	case strings.HasPrefix(term, "-") || strings.HasPrefix(term, "(") || strings.Contains(unquoteSearchTerm(term), ":") && !strings.HasPrefix(term, `"`):
+		return ""
+	}
+	return "word"
+}
+
+func (q *SearchQuery) applyTerm(term string) error {
+	negated := len(term) > 1 && term[0] == '-'
+	body := term
+	if negated {
+		body = term[1:]
+	}
+
+	if strings.HasPrefix(body, "(") {
+		q.Raw = append(q.Raw, term)
+		return nil
+	}
+	if strings.HasPrefix(body, `"`) {
+		if negated {
+			q.Exclude = append(q.Exclude, unquoteSearchTerm(body))
+		} else {
+			q.Phrases = append(q.Phrases, unquoteSearchTerm(body))
+		}
+		return nil
+	}
+
+	if key, value, ok := cutSearchOperator(body); ok {
+		if negated {
+			if key == "filter" {
+				q.ExcludeFilters = append(q.ExcludeFilters, SearchFilter(value))
+			} else {
+				q.Raw = append(q.Raw, term)
+			}
+			return nil
+		}
+		return q.applyOperator(term, key, value)
+	}
+
+	switch {
+	case strings.HasPrefix(body, "@") && len(body) > 1 && !negated:
+		q.Mentions = append(q.Mentions, body[1:])
+	case strings.HasPrefix(body, "#") && len(body) > 1 && !negated:
+		q.Hashtags = append(q.Hashtags, body[1:])
+	case negated:
+		q.Exclude = append(q.Exclude, body)
+	case strings.Contains(body, ":"):
+		q.Raw = append(q.Raw, term)
+	default:
+		q.Words = append(q.Words, body)
+	}
+	return nil
+}
+
+func (q *SearchQuery) applyOperator(term, key, value string) error {
+	var err error
+	switch key {
+	case "from":
+		q.From = append(q.From, value)
+	case "to":
+		q.To = append(q.To, value)
+	case "since", "since_time":
+		q.Since, err = parseSearchTime(key, value)
+	case "until", "until_time":
+		q.Until, err = parseSearchTime(key, value)
+	case "since_id":
+		q.SinceID = value
+	case "max_id":
+		q.MaxID = value
+	case "min_faves":
+		q.MinFaves, err = strconv.Atoi(value)
+	case "min_retweets":
+		q.MinRetweets, err = strconv.Atoi(value)
+	case "min_replies":
+		q.MinReplies, err = strconv.Atoi(value)
+	case "filter":
+		q.Filters = append(q.Filters, SearchFilter(value))
+	case "lang":
+		q.Lang = value
+	case "geocode":
+		q.GeoCode, err = parseSearchGeoCode(value)
+	case "near":
+		q.Near = value
+	case "within":
+		q.Within = value
+	case "conversation_id":
+		q.ConversationID = value
+	case "quoted_tweet_id":
+		q.QuotedTweetID = value
+	case "url":
+		q.URL = value
+	default:
+		q.Raw = append(q.Raw, term)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid search operator %q: %w", term, err)
+	}
+	return nil
+}
+
+var searchOperators = map[string]bool{
+	"from": true, "to": true, "since": true, "since_time": true, "until": true, "until_time": true,
+	"since_id": true, "max_id": true, "min_faves": true, "min_retweets": true, "min_replies": true,
+	"filter": true, "lang": true, "geocode": true, "near": true, "within": true,
+	"conversation_id": true, "quoted_tweet_id": true, "url": true,
+}
+
+// cutSearchOperator splits a known operator into key and unquoted value.
+func cutSearchOperator(term string) (string, string, bool) {
+	i := strings.Index(term, ":")
+	if i <= 0 || !searchOperators[term[:i]] {
+		return "", "", false
+	}
+	return term[:i], unquoteSearchTerm(term[i+1:]), true
+}
+
+// tokenizeSearchQuery splits a raw query into terms, quoted phrases and parentheses.
+func tokenizeSearchQuery(raw string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	flush := func() {
+		if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			token.WriteRune(r)
+		case quoted:
+			token.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		case r == '(' && (token.Len() == 0 || token.String() == "-"):
+			token.WriteRune(r)
+			flush()
+		case r == ')':
+			flush()
+			tokens = append(tokens, ")")
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, errors.New("search query has unclosed quote")
+	}
+	flush()
+	return tokens, nil
+}
+
+func validateSearchWord(word string) error {
+	if strings.TrimSpace(word) == "" {
+		return errors.New("search term is empty")
+	}
+	if strings.Contains(word, `"`) {
+		return fmt.Errorf("search term %q contains a double quote", word)
+	}
+	return nil
+}
+
+// quoteSearchTerm quotes terms which would otherwise be read as several terms or an operator.
+func quoteSearchTerm(term string) string {
+	if term == "OR" || strings.ContainsAny(term, " \t\n():") || strings.HasPrefix(term, "-") {
+		return `"` + term + `"`
+	}
+	return term
+}
+
+func unquoteSearchTerm(term string) string {
+	if len(term) >= 2 && strings.HasPrefix(term, `"`) && strings.HasSuffix(term, `"`) {
+		return term[1 : len(term)-1]
+	}
+	return term
+}
+
+func joinSearchGroup(terms []string) string {
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+func appendUsernames(terms []string, prefix string, usernames []string) []string {
+	var group []string
+	for _, username := range usernames {
+		group = append(group, prefix+strings.TrimPrefix(username, "@"))
+	}
+	if len(group) > 0 {
+		terms = append(terms, joinSearchGroup(group))
+	}
+	return terms
+}
+
+func formatSearchTime(key string, t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return key + ":" + t.Format("2006-01-02")
+	}
+	return key + ":" + t.Format(searchTimeLayout)
+}
+
+func parseSearchTime(key, value string) (time.Time, error) {
+	if strings.HasSuffix(key, "_time") {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	if len(value) == len("2006-01-02") {
+		return time.Parse("2006-01-02", value)
+	}
+	return time.Parse(searchTimeLayout, value)
+}
+
+func parseSearchGeoCode(value string) (*GeoCode, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 3 {
+		return nil, errors.New("geocode must be latitude,longitude,radius")
+	}
+	latitude, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, err
+	}
+	longitude, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, err
+	}
+	return &GeoCode{Latitude: latitude, Longitude: longitude, Radius: parts[2]}, nil
+}
@@ -0,0 +1,144 @@
+package twitterscraper_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestSearchQueryString(t *testing.T) {
+	query := twitterscraper.SearchQuery{
+		Words:          []string{"scraper"},
+		Phrases:        []string{"open source"},
+		AnyOf:          [][]string{{"go", "golang"}},
+		Exclude:        []string{"spam", "buy now"},
+		From:           []string{"x", "@Support"},
+		MinFaves:       10,
+		Since:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:          time.Date(2024, 2, 1, 12, 30, 0, 0, time.UTC),
+		Filters:        []twitterscraper.SearchFilter{twitterscraper.FilterMedia},
+		ExcludeFilters: []twitterscraper.SearchFilter{twitterscraper.FilterReplies},
+		Lang:           "en",
+		Near:           "New York",
+		Within:         "15mi",
+	}
+	raw, err := query.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `scraper "open source" (go OR golang) (from:x OR from:Support) -spam -"buy now" filter:media -filter:replies lang:en min_faves:10 since:2024-01-01 until:2024-02-01_12:30:00_UTC near:"New York" within:15mi`
+	if raw != expected {
+		t.Errorf("Expected query:\n%s\ngot:\n%s", expected, raw)
+	}
+}
+
+func TestSearchQueryStringQuotesOperators(t *testing.T) {
+	query := twitterscraper.SearchQuery{
+		Words:   []string{"from:x", "12:30"},
+		AnyOf:   [][]string{{"lang:en", "go"}},
+		Exclude: []string{"filter:links"},
+	}
+	expected := `"from:x" "12:30" ("lang:en" OR go) -"filter:links"`
+	if raw := query.String(); raw != expected {
+		t.Errorf("Expected words with a colon are quoted:\n%s\ngot:\n%s", expected, raw)
+	}
+	parsed, err := twitterscraper.ParseSearchQuery(query.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.From) != 0 || parsed.Lang != "" || len(parsed.ExcludeFilters) != 0 {
+		t.Errorf("Expected no operators in the query, got: %#v", parsed)
+	}
+}
+
+func TestSearchQueryValidate(t *testing.T) {
+	invalid := map[string]twitterscraper.SearchQuery{
+		"empty":           {},
+		"username":        {From: []string{"not a username"}},
+		"word with space": {Words: []string{"two words"}},
+		"quote":           {Phrases: []string{`say "hi"`}},
+		"dates":           {Since: time.Now(), Until: time.Now().Add(-time.Hour)},
+		"since id":        {Words: []string{"x"}, SinceID: "abc"},
+		"id range":        {Words: []string{"x"}, SinceID: "20", MaxID: "10"},
+		"filter":          {Filters: []twitterscraper.SearchFilter{"unknown"}},
+		"filter conflict": {Filters: []twitterscraper.SearchFilter{twitterscraper.FilterLinks}, ExcludeFilters: []twitterscraper.SearchFilter{twitterscraper.FilterLinks}},
+		"within":          {Words: []string{"x"}, Within: "10km"},
+		"geocode":         {GeoCode: &twitterscraper.GeoCode{Latitude: 100, Longitude: 0, Radius: "1km"}},
+		"negative count":  {Words: []string{"x"}, MinRetweets: -1},
+	}
+	for name, query := range invalid {
+		if err := query.Validate(); err == nil {
+			t.Errorf("Expected error for %s query: %s", name, query.String())
+		}
+	}
+}
+
+func TestSearchQuerySetIDRange(t *testing.T) {
+	query := twitterscraper.SearchQuery{Words: []string{"x"}}
+	query.SetIDRange(
+		time.Date(2023, 8, 31, 17, 45, 31, 115*int(time.Millisecond), time.UTC),
+		time.Date(2023, 8, 31, 17, 45, 31, 116*int(time.Millisecond), time.UTC),
+	)
+	// 1697304622749086011 is the only ID of these in the millisecond
+	if query.SinceID != "1697304622747615231" || query.MaxID != "1697304622751809535" {
+		t.Errorf("Unexpected since_id %s and max_id %s", query.SinceID, query.MaxID)
+	}
+	if err := query.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	query.SetIDRange(time.Time{}, time.Time{})
+	if query.SinceID != "1697304622747615231" || query.MaxID != "1697304622751809535" {
+		t.Error("Expected zero times leave bounds unchanged")
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	raw := `scraper "open source" (go OR golang) cats OR dogs #golang @x (from:x OR from:Support) -spam -filter:replies filter:links lang:en min_retweets:5 min_replies:2 since_time:1704067200 until:2024-02-01 since_id:1 max_id:2 conversation_id:3 quoted_tweet_id:4 url:github.com geocode:37.78,-122.39,1km is:reply -(a b)`
+	query, err := twitterscraper.ParseSearchQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &twitterscraper.SearchQuery{
+		Words:          []string{"scraper"},
+		Phrases:        []string{"open source"},
+		AnyOf:          [][]string{{"go", "golang"}, {"cats", "dogs"}},
+		Exclude:        []string{"spam"},
+		Hashtags:       []string{"golang"},
+		Mentions:       []string{"x"},
+		From:           []string{"x", "Support"},
+		Since:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:          time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		SinceID:        "1",
+		MaxID:          "2",
+		MinRetweets:    5,
+		MinReplies:     2,
+		Filters:        []twitterscraper.SearchFilter{twitterscraper.FilterLinks},
+		ExcludeFilters: []twitterscraper.SearchFilter{twitterscraper.FilterReplies},
+		Lang:           "en",
+		GeoCode:        &twitterscraper.GeoCode{Latitude: 37.78, Longitude: -122.39, Radius: "1km"},
+		ConversationID: "3",
+		QuotedTweetID:  "4",
+		URL:            "github.com",
+		Raw:            []string{"is:reply", "-(a b)"},
+	}
+	if !reflect.DeepEqual(query, expected) {
+		t.Errorf("Expected query %#v, got: %#v", expected, query)
+	}
+
+	again, err := twitterscraper.ParseSearchQuery(query.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, query) {
+		t.Errorf("Expected parsed String to be equal, got: %#v", again)
+	}
+
+	for _, raw := range []string{`"unclosed`, `(a OR b`, `a OR`, `(a OR (b OR c))`} {
+		if _, err := twitterscraper.ParseSearchQuery(raw); err == nil {
+			t.Errorf("Expected error for query %s", raw)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package twitterscraper_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	twitterscraper "github.com/imperatrona/twitter-scraper"
@@ -95,3 +96,113 @@ func TestGetSearchTweets(t *testing.T) {
 		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
 	}
 }
+
+func TestSearchTweetsWithOptions(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	testScraper.SetSearchMode(twitterscraper.SearchTop)
+	options := twitterscraper.SearchOptions{Product: twitterscraper.SearchProductLatest, PageSize: 20}
+	tweets, cursor, err := testScraper.FetchSearchTweetsWithOptions("twitter", 20, "", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor == "" {
+		t.Error("Expected search cursor is empty")
+	}
+	for i := 1; i < len(tweets); i++ {
+		if tweets[i-1].TimeParsed.Before(tweets[i].TimeParsed) {
+			t.Error("Expected latest tweets ordered from newest to oldest")
+		}
+	}
+}
+
+func TestGetSearchLists(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	maxListsNbr := 20
+	for list := range testScraper.SearchLists(context.Background(), "golang", maxListsNbr) {
+		if list.Error != nil {
+			t.Error(list.Error)
+		} else {
+			count++
+			if list.ID == "" {
+				t.Error("Expected list ID is empty")
+			}
+			if list.Name == "" {
+				t.Error("Expected list Name is empty")
+			}
+		}
+	}
+
+	if count == 0 {
+		t.Error("Expected lists are found")
+	}
+}
+
+func TestSearchTypeahead(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	typeahead, err := testScraper.SearchTypeahead("elon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(typeahead.Users) == 0 {
+		t.Error("Expected typeahead users are found")
+	}
+	for _, user := range typeahead.Users {
+		if user.Username == "" {
+			t.Error("Expected typeahead user Username is empty")
+		}
+	}
+}
+
+func TestSavedSearches(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	saved, err := testScraper.CreateSavedSearch("twitter scraper test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ID == "" {
+		t.Fatal("Expected saved search ID is empty")
+	}
+	if saved.Query != "twitter scraper test" {
+		t.Errorf("Expected saved search Query=%v, got: %v", "twitter scraper test", saved.Query)
+	}
+
+	searches, err := testScraper.GetSavedSearches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, search := range searches {
+		if search.ID == saved.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected saved search %v is listed", saved.ID)
+	}
+
+	if err := testScraper.DeleteSavedSearch(saved.ID); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteSavedSearchValidation(t *testing.T) {
+	scraper := twitterscraper.New()
+	for _, id := range []string{"", "123/../../account", "-1", "12a"} {
+		err := scraper.DeleteSavedSearch(id)
+		if err == nil || !strings.Contains(err.Error(), "invalid saved search id") {
+			t.Errorf("Expected invalid id error for %q, got: %v", id, err)
+		}
+	}
+	if err := scraper.DeleteSavedSearch("1234567890"); err == nil || err.Error() != "scraper is not logged in" {
+		t.Errorf("Expected not logged in error, got: %v", err)
+	}
+}
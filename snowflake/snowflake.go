@@ -0,0 +1,127 @@
+// Package snowflake decodes and builds Twitter snowflake IDs of tweets, users and media.
+//
+// An ID is a 63 bit number made of milliseconds since Epoch (41 bits), datacenter (5 bits),
+// worker (5 bits) and sequence (12 bits).
+package snowflake
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Epoch is the time of snowflake ID zero, 2010-11-04 01:42:54.657 UTC, in Unix milliseconds.
+const Epoch int64 = 1288834974657
+
+// SequentialLimit is the first ID which may be a snowflake. Lower IDs were assigned
+// sequentially before snowflakes were introduced and carry no time.
+const SequentialLimit uint64 = 30000000000
+
+const (
+	timestampShift  = 22
+	datacenterShift = 17
+	workerShift     = 12
+	fieldMask       = 1<<5 - 1
+	sequenceMask    = 1<<12 - 1
+	maxTimestamp    = 1<<41 - 1
+)
+
+// ErrNotSnowflake is returned for IDs assigned before snowflakes.
+var ErrNotSnowflake = errors.New("id is not a snowflake")
+
+// ID is a decoded snowflake ID.
+type ID struct {
+	Time       time.Time
+	Datacenter int
+	Worker     int
+	Sequence   int
+}
+
+// Parse decodes a snowflake ID.
+func Parse(id string) (ID, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return ID{}, err
+	}
+	if n < SequentialLimit {
+		return ID{}, ErrNotSnowflake
+	}
+	return ID{
+		Time:       unixMilli(int64(n>>timestampShift) + Epoch),
+		Datacenter: int(n >> datacenterShift & fieldMask),
+		Worker:     int(n >> workerShift & fieldMask),
+		Sequence:   int(n & sequenceMask),
+	}, nil
+}
+
+// Time returns the creation time of a snowflake ID in UTC, with millisecond precision.
+func Time(id string) (time.Time, error) {
+	decoded, err := Parse(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return decoded.Time, nil
+}
+
+// MinID returns the smallest ID created in the millisecond of t.
+// Times before Epoch return "0".
+func MinID(t time.Time) string {
+	ms := timestamp(t)
+	if ms < 0 {
+		return "0"
+	}
+	return strconv.FormatInt(ms<<timestampShift, 10)
+}
+
+// MaxID returns the largest ID created in the millisecond of t.
+// Times before Epoch return "0".
+func MaxID(t time.Time) string {
+	ms := timestamp(t)
+	if ms < 0 {
+		return "0"
+	}
+	return strconv.FormatInt(ms<<timestampShift|(1<<timestampShift-1), 10)
+}
+
+// Compare compares numeric IDs as integers, it returns -1, 0 or +1.
+// Values which are not numbers are compared by length and then lexically.
+func Compare(a, b string) int {
+	x, errA := strconv.ParseUint(a, 10, 64)
+	y, errB := strconv.ParseUint(b, 10, 64)
+	if errA != nil || errB != nil {
+		// IDs of the same length compare as numbers
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// timestamp returns milliseconds of t since Epoch, at most the largest snowflake timestamp.
+func timestamp(t time.Time) int64 {
+	ms := t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond) - Epoch
+	if ms > maxTimestamp {
+		return maxTimestamp
+	}
+	return ms
+}
+
+func unixMilli(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
@@ -0,0 +1,89 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+const tweetID = "1697304622749086011"
+
+var tweetTime = time.Date(2023, 8, 31, 17, 45, 31, 115*int(time.Millisecond), time.UTC)
+
+func TestParse(t *testing.T) {
+	id, err := snowflake.Parse(tweetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := snowflake.ID{Time: tweetTime, Datacenter: 11, Worker: 7, Sequence: 315}
+	if id != expected {
+		t.Errorf("Expected %+v, got %+v", expected, id)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := snowflake.Parse("20"); err != snowflake.ErrNotSnowflake {
+		t.Errorf("Expected ErrNotSnowflake for a sequential ID, got %v", err)
+	}
+	if _, err := snowflake.Parse("abc"); err == nil {
+		t.Error("Expected error for a non numeric ID")
+	}
+	if tm, err := snowflake.Time(""); err == nil || !tm.IsZero() {
+		t.Errorf("Expected error and zero time for an empty ID, got %v %v", tm, err)
+	}
+}
+
+func TestTime(t *testing.T) {
+	tm, err := snowflake.Time(tweetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tm.Equal(tweetTime) {
+		t.Errorf("Expected %v, got %v", tweetTime, tm)
+	}
+}
+
+func TestMinMaxID(t *testing.T) {
+	if id := snowflake.MinID(tweetTime); id != "1697304622747615232" {
+		t.Errorf("Unexpected MinID %s", id)
+	}
+	if id := snowflake.MaxID(tweetTime); id != "1697304622751809535" {
+		t.Errorf("Unexpected MaxID %s", id)
+	}
+	if snowflake.Compare(snowflake.MinID(tweetTime), tweetID) > 0 || snowflake.Compare(snowflake.MaxID(tweetTime), tweetID) < 0 {
+		t.Error("Expected the ID is between MinID and MaxID of its time")
+	}
+	for _, id := range []string{snowflake.MinID(tweetTime), snowflake.MaxID(tweetTime)} {
+		if tm, _ := snowflake.Time(id); !tm.Equal(tweetTime) {
+			t.Errorf("Expected %s decodes to %v, got %v", id, tweetTime, tm)
+		}
+	}
+
+	before := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if snowflake.MinID(before) != "0" || snowflake.MaxID(before) != "0" {
+		t.Error("Expected 0 for times before Epoch")
+	}
+	if id := snowflake.MaxID(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)); id != "9223372036854775807" {
+		t.Errorf("Expected the largest ID for far future, got %s", id)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2", "10", -1},
+		{"10", "2", 1},
+		{tweetID, tweetID, 0},
+		{"1697304622749086010", tweetID, -1},
+		{"abc", "abd", -1},
+		{"ab", "a", 1},
+	}
+	for _, test := range tests {
+		if got := snowflake.Compare(test.a, test.b); got != test.expected {
+			t.Errorf("Compare(%q, %q) = %d, expected %d", test.a, test.b, got, test.expected)
+		}
+	}
+}
@@ -73,38 +73,38 @@ func (s *Scraper) GetSpace(id string) (*Space, error) {
 }
 
 type Topic struct {
-	ID    string
-	Title string
+	ID    string `json:"id"`
+	Title string `json:"title"`
 }
 
 type SpaceUser struct {
-	UserID      string
-	Username    string
-	Name        string
-	Avatar      string
-	IsVerified  bool
-	ConnectedAt time.Time
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	Name        string    `json:"name"`
+	Avatar      string    `json:"avatar"`
+	IsVerified  bool      `json:"is_verified"`
+	ConnectedAt time.Time `json:"connected_at"`
 }
 
 type SpaceParticipants struct {
-	TotalCount   int
-	CurrentCount int
-	Admins       []*SpaceUser
-	Speakers     []*SpaceUser
-	Listeners    []*SpaceUser
+	TotalCount   int          `json:"total_count"`
+	CurrentCount int          `json:"current_count"`
+	Admins       []*SpaceUser `json:"admins,omitempty"`
+	Speakers     []*SpaceUser `json:"speakers,omitempty"`
+	Listeners    []*SpaceUser `json:"listeners,omitempty"`
 }
 
 type Space struct {
-	ID             string
-	State          string
-	Title          string
-	ContentType    string
-	Topics         []Topic
-	Participants   SpaceParticipants
-	CreatedAt      time.Time
-	ScheduledStart time.Time
-	StartedAt      time.Time
-	UpdatedAt      time.Time
+	ID             string            `json:"id"`
+	State          string            `json:"state"`
+	Title          string            `json:"title"`
+	ContentType    string            `json:"content_type"`
+	Topics         []Topic           `json:"topics,omitempty"`
+	Participants   SpaceParticipants `json:"participants"`
+	CreatedAt      time.Time         `json:"created_at"`
+	ScheduledStart time.Time         `json:"scheduled_start"`
+	StartedAt      time.Time         `json:"started_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 }
 
 type spaceUser struct {
@@ -0,0 +1,139 @@
+package twitterscraper
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+// TimelineOptions bounds a tweet timeline by time or tweet ID.
+// Bounds expect the timeline to be ordered from newest to oldest, pinned tweets are
+// checked against them but never stop paging. Timelines ordered differently, like
+// bookmarks which are ordered by bookmark time, can stop earlier than expected.
+type TimelineOptions struct {
+	// Since stops paging once a tweet older than it is reached.
+	Since time.Time
+	// SinceID stops paging once a tweet with ID lower or equal to it is reached.
+	SinceID string
+	// Until skips tweets created after it.
+	Until time.Time
+	// MaxID skips tweets with ID greater than it.
+	MaxID string
+	// OrganicOnly drops promoted tweets and parent tweets shown in conversation modules.
+	OrganicOnly bool
+	// AllowDuplicates disables deduplication of tweets by ID within the stream.
+	AllowDuplicates bool
+	// DedupeWindow bounds deduplication memory to the last DedupeWindow tweet IDs,
+	// 0 uses DefaultDedupeWindow and a negative value remembers every ID of the stream.
+	DedupeWindow int
+}
+
+// DefaultDedupeWindow is the number of last tweet IDs remembered for deduplication
+// by default. Duplicates come from adjacent pages, so it's far more than a page.
+const DefaultDedupeWindow = 1000
+
+func (options *TimelineOptions) dedupeWindow() int {
+	if options.DedupeWindow == 0 {
+		return DefaultDedupeWindow
+	}
+	return options.DedupeWindow
+}
+
+// values encodes the set options, they are empty for zero options.
+func (options *TimelineOptions) values() url.Values {
+	values := url.Values{}
+	if !options.Since.IsZero() {
+		values.Set("since", options.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if options.SinceID != "" {
+		values.Set("since_id", options.SinceID)
+	}
+	if !options.Until.IsZero() {
+		values.Set("until", options.Until.UTC().Format(time.RFC3339Nano))
+	}
+	if options.MaxID != "" {
+		values.Set("max_id", options.MaxID)
+	}
+	if options.OrganicOnly {
+		values.Set("organic_only", "true")
+	}
+	if options.AllowDuplicates {
+		values.Set("allow_duplicates", "true")
+	}
+	if options.DedupeWindow != 0 {
+		values.Set("dedupe_window", strconv.Itoa(options.DedupeWindow))
+	}
+	return values
+}
+
+type bound int
+
+const (
+	boundEmit bound = iota
+	boundSkip
+	boundStop
+)
+
+func (options *TimelineOptions) bound(tweet *Tweet) bound {
+	older := (!options.Since.IsZero() && !tweet.TimeParsed.IsZero() && tweet.TimeParsed.Before(options.Since)) ||
+		(options.SinceID != "" && snowflake.Compare(tweet.ID, options.SinceID) <= 0)
+	if older {
+		// pinned and non-organic tweets are out of chronological order
+		if tweet.IsPin || tweet.EntryType != EntryOrganic {
+			return boundSkip
+		}
+		return boundStop
+	}
+
+	newer := (!options.Until.IsZero() && tweet.TimeParsed.After(options.Until)) ||
+		(options.MaxID != "" && snowflake.Compare(tweet.ID, options.MaxID) > 0)
+	if newer {
+		return boundSkip
+	}
+
+	return boundEmit
+}
+
+// seenIDs remembers tweet IDs emitted by a stream, only the last window of them
+// if window is positive.
+type seenIDs struct {
+	window int
+	ids    map[string]struct{}
+	order  []string
+	next   int
+}
+
+func newSeenIDs(window int) *seenIDs {
+	return &seenIDs{window: window, ids: make(map[string]struct{})}
+}
+
+// add returns false if id was already seen.
+func (seen *seenIDs) add(id string) bool {
+	if _, ok := seen.ids[id]; ok {
+		return false
+	}
+	seen.ids[id] = struct{}{}
+	if seen.window <= 0 || len(seen.order) < seen.window {
+		seen.order = append(seen.order, id)
+	} else {
+		delete(seen.ids, seen.order[seen.next])
+		seen.order[seen.next] = id
+		seen.next = (seen.next + 1) % seen.window
+	}
+	return true
+}
+
+// has returns true if id is remembered.
+func (seen *seenIDs) has(id string) bool {
+	_, ok := seen.ids[id]
+	return ok
+}
+
+// list returns the remembered IDs from the oldest to the newest.
+func (seen *seenIDs) list() []string {
+	list := make([]string, 0, len(seen.order))
+	list = append(list, seen.order[seen.next:]...)
+	return append(list, seen.order[:seen.next]...)
+}
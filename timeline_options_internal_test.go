@@ -0,0 +1,116 @@
+package twitterscraper
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSeenIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   int
+		ids      []string
+		added    []bool
+		expected []string
+	}{
+		{
+			name:     "unbounded",
+			window:   -1,
+			ids:      []string{"1", "2", "1", "3", "2"},
+			added:    []bool{true, true, false, true, false},
+			expected: []string{"1", "2", "3"},
+		},
+		{
+			name:     "window forgets oldest",
+			window:   2,
+			ids:      []string{"1", "2", "1", "3", "1", "2"},
+			added:    []bool{true, true, false, true, true, true},
+			expected: []string{"1", "2"},
+		},
+		{
+			name:     "window keeps order after wrap",
+			window:   3,
+			ids:      []string{"1", "2", "3", "4", "5"},
+			added:    []bool{true, true, true, true, true},
+			expected: []string{"3", "4", "5"},
+		},
+	}
+	for _, test := range tests {
+		seen := newSeenIDs(test.window)
+		for i, id := range test.ids {
+			if added := seen.add(id); added != test.added[i] {
+				t.Errorf("%s: add(%s) #%d = %v, expected %v", test.name, id, i, added, test.added[i])
+			}
+		}
+		if list := seen.list(); !reflect.DeepEqual(list, test.expected) {
+			t.Errorf("%s: list() = %v, expected %v", test.name, list, test.expected)
+		}
+		if len(seen.ids) != len(test.expected) {
+			t.Errorf("%s: expected %d remembered IDs, got %d", test.name, len(test.expected), len(seen.ids))
+		}
+	}
+}
+
+func TestTimelineOptionsDedupeWindow(t *testing.T) {
+	tests := map[int]int{0: DefaultDedupeWindow, 20: 20, -1: -1}
+	for window, expected := range tests {
+		options := TimelineOptions{DedupeWindow: window}
+		if actual := options.dedupeWindow(); actual != expected {
+			t.Errorf("dedupeWindow() for %d = %d, expected %d", window, actual, expected)
+		}
+	}
+}
+
+func TestTimelineOptionsBound(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tweet := func(id string, created time.Time, pinned bool, entryType EntryType) *Tweet {
+		return &Tweet{ID: id, TimeParsed: created, IsPin: pinned, EntryType: entryType}
+	}
+	tests := []struct {
+		name     string
+		options  TimelineOptions
+		tweet    *Tweet
+		expected bound
+	}{
+		{"no bounds", TimelineOptions{}, tweet("5", day, false, EntryOrganic), boundEmit},
+		{"after since", TimelineOptions{Since: day.Add(-time.Hour)}, tweet("5", day, false, EntryOrganic), boundEmit},
+		{"before since", TimelineOptions{Since: day.Add(time.Hour)}, tweet("5", day, false, EntryOrganic), boundStop},
+		{"pinned before since", TimelineOptions{Since: day.Add(time.Hour)}, tweet("5", day, true, EntryOrganic), boundSkip},
+		{"promoted before since", TimelineOptions{Since: day.Add(time.Hour)}, tweet("5", day, false, EntryPromoted), boundSkip},
+		{"unknown time with since", TimelineOptions{Since: day}, tweet("5", time.Time{}, false, EntryOrganic), boundEmit},
+		{"above since id", TimelineOptions{SinceID: "4"}, tweet("5", day, false, EntryOrganic), boundEmit},
+		{"equal since id", TimelineOptions{SinceID: "5"}, tweet("5", day, false, EntryOrganic), boundStop},
+		{"since id compared as number", TimelineOptions{SinceID: "10"}, tweet("9", day, false, EntryOrganic), boundStop},
+		{"after until", TimelineOptions{Until: day.Add(-time.Hour)}, tweet("5", day, false, EntryOrganic), boundSkip},
+		{"before until", TimelineOptions{Until: day.Add(time.Hour)}, tweet("5", day, false, EntryOrganic), boundEmit},
+		{"above max id", TimelineOptions{MaxID: "4"}, tweet("5", day, false, EntryOrganic), boundSkip},
+		{"equal max id", TimelineOptions{MaxID: "5"}, tweet("5", day, false, EntryOrganic), boundEmit},
+		{"stop wins over skip", TimelineOptions{SinceID: "6", MaxID: "4"}, tweet("5", day, false, EntryOrganic), boundStop},
+	}
+	for _, test := range tests {
+		if actual := test.options.bound(test.tweet); actual != test.expected {
+			t.Errorf("%s: bound() = %v, expected %v", test.name, actual, test.expected)
+		}
+	}
+}
+
+func TestParseEntryType(t *testing.T) {
+	tests := []struct {
+		entryID  string
+		promoted bool
+		expected EntryType
+	}{
+		{"tweet-1697304622749086011", false, EntryOrganic},
+		{"tweet-1697304622749086011", true, EntryPromoted},
+		{"promoted-tweet-1697304622749086011-3f8c", false, EntryPromoted},
+		{"promotedTweet-1697304622749086011", false, EntryPromoted},
+		{"home-conversation-1697304622749086011-tweet-1697304622749086010", false, EntryOrganic},
+		{"", false, EntryOrganic},
+	}
+	for _, test := range tests {
+		if actual := parseEntryType(test.entryID, test.promoted); actual != test.expected {
+			t.Errorf("parseEntryType(%q, %v) = %v, expected %v", test.entryID, test.promoted, actual, test.expected)
+		}
+	}
+}
@@ -88,16 +88,29 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 		username := timeline.GlobalObjects.Users[tweet.UserIDStr].ScreenName
 		name := timeline.GlobalObjects.Users[tweet.UserIDStr].Name
 		tw := &Tweet{
-			ID:             id,
-			ConversationID: tweet.ConversationIDStr,
-			Likes:          tweet.FavoriteCount,
-			Name:           name,
-			PermanentURL:   fmt.Sprintf("https://twitter.com/%s/status/%s", username, id),
-			Replies:        tweet.ReplyCount,
-			Retweets:       tweet.RetweetCount,
-			Text:           tweet.FullText,
-			UserID:         tweet.UserIDStr,
-			Username:       username,
+			ID:                id,
+			Bookmarks:         tweet.BookmarkCount,
+			ConversationID:    tweet.ConversationIDStr,
+			IsBlueVerified:    timeline.GlobalObjects.Users[tweet.UserIDStr].IsBlueVerified,
+			Lang:              tweet.Lang,
+			Likes:             tweet.FavoriteCount,
+			Name:              name,
+			PermanentURL:      fmt.Sprintf("https://twitter.com/%s/status/%s", username, id),
+			PossiblySensitive: tweet.PossiblySensitive,
+			Quotes:            tweet.QuoteCount,
+			Replies:           tweet.ReplyCount,
+			Retweets:          tweet.RetweetCount,
+			Source:            parseSource(tweet.Source),
+			Text:              tweet.FullText,
+			UserID:            tweet.UserIDStr,
+			Username:          username,
+		}
+		if tweet.ConversationControl != nil {
+			tw.ReplyPolicy = tweet.ConversationControl.Policy
+		}
+		if user, ok := timeline.GlobalObjects.Users[tweet.UserIDStr]; ok {
+			author := parseProfile(user)
+			tw.Author = &author
 		}
 
 		tm, err := time.Parse(time.RubyDate, tweet.CreatedAt)
@@ -183,8 +196,8 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 			}
 
 			if !tw.SensitiveContent {
-				sensitive := media.ExtSensitiveMediaWarning
-				tw.SensitiveContent = sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other
+				sensitive := media.sensitiveWarning()
+				tw.SensitiveContent = sensitive != nil && (sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other)
 			}
 		}
 
@@ -192,49 +205,18 @@ func (timeline *timelineV1) parseTweet(id string) *Tweet {
 			tw.URLs = append(tw.URLs, url.ExpandedURL)
 		}
 
-		tw.HTML = tweet.FullText
-		tw.HTML = reHashtag.ReplaceAllStringFunc(tw.HTML, func(hashtag string) string {
-			return fmt.Sprintf(`<a href="https://twitter.com/hashtag/%s">%s</a>`,
-				strings.TrimPrefix(hashtag, "#"),
-				hashtag,
-			)
-		})
-		tw.HTML = reUsername.ReplaceAllStringFunc(tw.HTML, func(username string) string {
-			return fmt.Sprintf(`<a href="https://twitter.com/%s">%s</a>`,
-				strings.TrimPrefix(username, "@"),
-				username,
-			)
-		})
-		var foundedMedia []string
-		tw.HTML = reTwitterURL.ReplaceAllStringFunc(tw.HTML, func(tco string) string {
-			for _, entity := range tweet.Entities.URLs {
-				if tco == entity.URL {
-					return fmt.Sprintf(`<a href="%s">%s</a>`, entity.ExpandedURL, tco)
-				}
-			}
-			for _, entity := range tweet.ExtendedEntities.Media {
-				if tco == entity.URL {
-					foundedMedia = append(foundedMedia, entity.MediaURLHttps)
-					return fmt.Sprintf(`<br><a href="%s"><img src="%s"/></a>`, tco, entity.MediaURLHttps)
-				}
-			}
-			return tco
-		})
-		for _, photo := range tw.Photos {
-			url := photo.URL
-			if stringInSlice(url, foundedMedia) {
-				continue
+		tw.DisplayTextRange = parseIndices(tweet.DisplayTextRange)
+		tw.Entities = parseEntities(&tweet)
+		tw.Media = parseMedia(&tweet)
+		if tweet.Card != nil {
+			if tw.Card = tweet.Card.parse(tw); tw.Card != nil {
+				tw.Card.ID = tweet.CardURI
 			}
-			tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
 		}
-		for _, video := range tw.Videos {
-			url := video.Preview
-			if stringInSlice(url, foundedMedia) {
-				continue
-			}
-			tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
+		if tweet.EditControl != nil {
+			tw.EditControl = tweet.EditControl.parse(tw.ID)
 		}
-		tw.HTML = strings.Replace(tw.HTML, "\n", "<br>", -1)
+
 		return tw
 	}
 	return nil
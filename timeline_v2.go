@@ -3,15 +3,13 @@ package twitterscraper
 import (
 	"strconv"
 	"strings"
+	"time"
 )
 
 type tweet struct {
 	Core struct {
 		UserResults struct {
-			Result struct {
-				IsBlueVerified bool       `json:"is_blue_verified"`
-				Legacy         legacyUser `json:"legacy"`
-			} `json:"result"`
+			Result legacyUserResult `json:"result"`
 		} `json:"user_results"`
 	} `json:"core"`
 	Views struct {
@@ -19,46 +17,126 @@ type tweet struct {
 	} `json:"views"`
 	NoteTweet struct {
 		NoteTweetResults struct {
-			Result struct {
-				Text string `json:"text"`
-			} `json:"result"`
+			Result noteTweetResult `json:"result"`
 		} `json:"note_tweet_results"`
 	} `json:"note_tweet"`
+	Article struct {
+		ArticleResults struct {
+			Result *articleResult `json:"result"`
+		} `json:"article_results"`
+	} `json:"article"`
 	QuotedStatusResult struct {
 		Result *result `json:"result"`
 	} `json:"quoted_status_result"`
+	Card *struct {
+		RestID string     `json:"rest_id"`
+		Legacy legacyCard `json:"legacy"`
+	} `json:"card"`
+	BirdwatchPivot       *birdwatchPivot `json:"birdwatch_pivot"`
+	EditControl          *editControl    `json:"edit_control"`
+	PreviousCounts       *previousCounts `json:"previous_counts"`
+	Source               string          `json:"source"`
+	IsTranslatable       bool            `json:"is_translatable"`
+	HasSuperFollower     bool            `json:"has_super_follower"`
+	LimitedActionResults struct {
+		LimitedActions []struct {
+			Action string `json:"action"`
+		} `json:"limited_actions"`
+	} `json:"limitedActionResults"`
 	Legacy legacyTweet `json:"legacy"`
 }
 
 type result struct {
 	Typename string `json:"__typename"`
 	tweet
-	Tweet tweet `json:"tweet"`
+	Tweet             tweet          `json:"tweet"`
+	Tombstone         *tombstoneText `json:"tombstone"`
+	Reason            string         `json:"reason"`
+	TweetInterstitial *tombstoneText `json:"tweetInterstitial"`
+	Raw               rawJSON        `json:"-"`
 }
 
 func (result *result) parse() *Tweet {
-	if result.NoteTweet.NoteTweetResults.Result.Text != "" {
-		result.Legacy.FullText = result.NoteTweet.NoteTweetResults.Result.Text
+	if result.Raw.Keep {
+		result.tweet.keepRaw()
+		result.Tweet.keepRaw()
 	}
-	var legacy *legacyTweet = &result.Legacy
-	var user *legacyUser = &result.Core.UserResults.Result.Legacy
+	var tw *Tweet
 	if result.Typename == "TweetWithVisibilityResults" {
-		legacy = &result.Tweet.Legacy
-		user = &result.Tweet.Core.UserResults.Result.Legacy
+		tw = result.Tweet.parse()
+		// limited actions are set on the visibility results wrapper
+		if tw != nil && tw.LimitedActions == nil {
+			tw.LimitedActions = result.tweet.limitedActions()
+		}
+		if tw != nil {
+			tw.Unavailable = result.interstitial()
+		}
+	} else {
+		tw = result.tweet.parse()
+	}
+	if tw != nil {
+		tw.Raw = result.Raw.payload()
 	}
-	tw := parseLegacyTweet(user, legacy)
+	return tw
+}
+
+func (tweet *tweet) parse() *Tweet {
+	note := &tweet.NoteTweet.NoteTweetResults.Result
+	if note.Text != "" {
+		note.apply(&tweet.Legacy)
+	}
+	tw := parseLegacyTweet(&tweet.Core.UserResults.Result.Legacy, &tweet.Legacy)
 	if tw == nil {
 		return nil
 	}
-	if tw.Views == 0 && result.Views.Count != "" {
-		tw.Views, _ = strconv.Atoi(result.Views.Count)
+	if user := tweet.Core.UserResults.Result; user.Legacy.ScreenName != "" {
+		author := user.parse()
+		tw.Author = &author
+	}
+	tw.IsBlueVerified = tweet.Core.UserResults.Result.IsBlueVerified
+	tw.IsTranslatable = tweet.IsTranslatable
+	tw.HasSuperFollower = tweet.HasSuperFollower
+	if tweet.Source != "" {
+		tw.Source = parseSource(tweet.Source)
+	}
+	tw.LimitedActions = tweet.limitedActions()
+	if note.Text != "" {
+		tw.NoteTweet = note.parse()
 	}
-	if result.QuotedStatusResult.Result != nil {
-		tw.QuotedStatus = result.QuotedStatusResult.Result.parse()
+	if tweet.Article.ArticleResults.Result != nil {
+		tw.Article = tweet.Article.ArticleResults.Result.parse()
+	}
+	if tw.Views == 0 && tweet.Views.Count != "" {
+		tw.Views, _ = strconv.Atoi(tweet.Views.Count)
+	}
+	if tweet.QuotedStatusResult.Result != nil {
+		tw.QuotedStatus = tweet.QuotedStatusResult.Result.parseWithUnavailable(tw.QuotedStatusID)
+	}
+	if tweet.Card != nil {
+		if tw.Card = tweet.Card.Legacy.parse(tw); tw.Card != nil {
+			tw.Card.ID = tweet.Card.RestID
+		}
+	}
+	if tweet.BirdwatchPivot != nil {
+		tw.CommunityNote = tweet.BirdwatchPivot.parse(tw.ID)
+	}
+	if tweet.EditControl != nil {
+		tw.EditControl = tweet.EditControl.parse(tw.ID)
+	}
+	if tweet.PreviousCounts != nil {
+		tw.PreviousCounts = tweet.PreviousCounts.parse()
 	}
 	return tw
 }
 
+func (tweet *tweet) limitedActions() []string {
+	var actions []string
+	for _, action := range tweet.LimitedActionResults.LimitedActions {
+		actions = append(actions, action.Action)
+	}
+	return actions
+}
+
 type userResult struct {
 	Typename                   string       `json:"__typename"`
 	ID                         string       `json:"id"`
@@ -68,12 +146,34 @@ type userResult struct {
 	IsBlueVerified             bool         `json:"is_blue_verified"`
 	ProfileImageShape          string       `json:"profile_image_shape"`
 	Legacy                     legacyUserV2 `json:"legacy"`
+	Raw                        rawJSON      `json:"-"`
 }
 
 func (result *userResult) parse() Profile {
 	return parseProfileV2(*result)
 }
 
+// legacyUserResult is a user result with the legacy user object of API v1.
+type legacyUserResult struct {
+	RestID         string     `json:"rest_id"`
+	IsBlueVerified bool       `json:"is_blue_verified"`
+	Legacy         legacyUser `json:"legacy"`
+	Message        string     `json:"message"`
+	Raw            rawJSON    `json:"-"`
+}
+
+func (result *legacyUserResult) parse() Profile {
+	if result.Legacy.IDStr == "" {
+		result.Legacy.IDStr = result.RestID
+	}
+	profile := parseProfile(result.Legacy)
+	if result.IsBlueVerified {
+		profile.IsBlueVerified = true
+	}
+	profile.Raw = result.Raw.payload()
+	return profile
+}
+
 type item struct {
 	EntryID string `json:"entryId"`
 	Item    struct {
@@ -83,13 +183,16 @@ type item struct {
 			TweetResults     struct {
 				Result result `json:"result"`
 			} `json:"tweet_results"`
-			CursorType string `json:"cursorType"`
-			Value      string `json:"value"`
+			PromotedMetadata *struct{}   `json:"promotedMetadata"`
+			List             *listResult `json:"list"`
+			CursorType       string      `json:"cursorType"`
+			Value            string      `json:"value"`
 		} `json:"itemContent"`
 	} `json:"item"`
 }
 
 type entry struct {
+	EntryID string `json:"entryId"`
 	Content struct {
 		CursorType  string `json:"cursorType"`
 		Value       string `json:"value"`
@@ -100,8 +203,10 @@ type entry struct {
 			TweetResults     struct {
 				Result result `json:"result"`
 			} `json:"tweet_results"`
-			UserDisplayType string `json:"userDisplayType"`
-			UserResults     struct {
+			PromotedMetadata *struct{}   `json:"promotedMetadata"`
+			List             *listResult `json:"list"`
+			UserDisplayType  string      `json:"userDisplayType"`
+			UserResults      struct {
 				Result userResult `json:"result"`
 			} `json:"user_results"`
 			CursorType string `json:"cursorType"`
@@ -110,6 +215,53 @@ type entry struct {
 	} `json:"content"`
 }
 
+type listResult struct {
+	IDStr              string      `json:"id_str"`
+	Name               string      `json:"name"`
+	Description        string      `json:"description"`
+	MemberCount        int         `json:"member_count"`
+	SubscriberCount    int         `json:"subscriber_count"`
+	Mode               string      `json:"mode"`
+	Following          bool        `json:"following"`
+	CreatedAt          int64       `json:"created_at"`
+	CustomBannerMedia  *listBanner `json:"custom_banner_media"`
+	DefaultBannerMedia *listBanner `json:"default_banner_media"`
+	UserResults        struct {
+		Result *userResult `json:"result"`
+	} `json:"user_results"`
+}
+
+type listBanner struct {
+	MediaInfo struct {
+		OriginalImgURL string `json:"original_img_url"`
+	} `json:"media_info"`
+}
+
+func (result *listResult) parse() *List {
+	list := &List{
+		ID:              result.IDStr,
+		Name:            result.Name,
+		Description:     result.Description,
+		MemberCount:     result.MemberCount,
+		SubscriberCount: result.SubscriberCount,
+		Private:         result.Mode == "Private",
+		Following:       result.Following,
+	}
+	if result.CreatedAt > 0 {
+		list.CreatedAt = time.Unix(0, result.CreatedAt*int64(time.Millisecond))
+	}
+	if result.CustomBannerMedia != nil {
+		list.BannerURL = result.CustomBannerMedia.MediaInfo.OriginalImgURL
+	} else if result.DefaultBannerMedia != nil {
+		list.BannerURL = result.DefaultBannerMedia.MediaInfo.OriginalImgURL
+	}
+	if result.UserResults.Result != nil {
+		owner := result.UserResults.Result.parse()
+		list.Owner = &owner
+	}
+	return list
+}
+
 // timeline v2 JSON object
 type timelineV2 struct {
 	Data struct {
@@ -151,21 +303,32 @@ func (timeline *timelineV2) parseTweets() ([]*Tweet, string) {
 			}
 			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					tweet.EntryType = parseEntryType(entry.EntryID, entry.Content.ItemContent.PromotedMetadata != nil)
 					tweets = append(tweets, tweet)
 				}
 			}
 			if len(entry.Content.Items) > 0 {
+				var module []*Tweet
 				for _, item := range entry.Content.Items {
 					if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
-						tweets = append(tweets, tweet)
+						tweet.EntryType = parseEntryType(entry.EntryID, item.Item.ItemContent.PromotedMetadata != nil)
+						module = append(module, tweet)
+					}
+				}
+				// conversation modules show parent tweets above the last one
+				for i := 0; i < len(module)-1; i++ {
+					if module[i].EntryType == EntryOrganic {
+						module[i].EntryType = EntryModuleContext
 					}
 				}
+				tweets = append(tweets, module...)
 			}
 		}
 		if len(instruction.ModuleItems) > 0 {
 			for _, entry := range instruction.ModuleItems {
 				if entry.Item.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Item.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
 					if tweet := entry.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+						tweet.EntryType = parseEntryType(entry.EntryID, entry.Item.ItemContent.PromotedMetadata != nil)
 						tweets = append(tweets, tweet)
 					}
 				}
@@ -199,6 +362,7 @@ func (timeline *bookmarksTimelineV2) parseTweets() ([]*Tweet, string) {
 			}
 			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					tweet.EntryType = parseEntryType(entry.EntryID, entry.Content.ItemContent.PromotedMetadata != nil)
 					tweets = append(tweets, tweet)
 				}
 			}
@@ -207,23 +371,61 @@ func (timeline *bookmarksTimelineV2) parseTweets() ([]*Tweet, string) {
 	return tweets, cursor
 }
 
-type retweetersTimelineV2 struct {
+type listTimelineV2 struct {
 	Data struct {
-		RetweetersTimeline struct {
-			Timeline struct {
-				Instructions []struct {
-					Type    string  `json:"type"`
-					Entries []entry `json:"entries"`
-				} `json:"instructions"`
-			} `json:"timeline"`
-		} `json:"retweeters_timeline"`
+		List struct {
+			TweetsTimeline struct {
+				Timeline struct {
+					Instructions []struct {
+						Entries []entry `json:"entries"`
+						Type    string  `json:"type"`
+					} `json:"instructions"`
+				} `json:"timeline"`
+			} `json:"tweets_timeline"`
+		} `json:"list"`
 	} `json:"data"`
 }
 
-func (timeline *retweetersTimelineV2) parseUsers() ([]*Profile, string) {
+func (timeline *listTimelineV2) parseTweets() ([]*Tweet, string) {
+	var cursor string
+	var tweets []*Tweet
+	for _, instruction := range timeline.Data.List.TweetsTimeline.Timeline.Instructions {
+		for _, entry := range instruction.Entries {
+			if entry.Content.CursorType == "Bottom" {
+				cursor = entry.Content.Value
+				continue
+			}
+			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
+				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					tweet.EntryType = parseEntryType(entry.EntryID, entry.Content.ItemContent.PromotedMetadata != nil)
+					tweets = append(tweets, tweet)
+				}
+			}
+			for _, item := range entry.Content.Items {
+				if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					tweet.EntryType = parseEntryType(entry.EntryID, item.Item.ItemContent.PromotedMetadata != nil)
+					tweets = append(tweets, tweet)
+				}
+			}
+		}
+	}
+	return tweets, cursor
+}
+
+// userTimelineV2 is a timeline of users who engaged with a tweet.
+type userTimelineV2 struct {
+	Instructions []struct {
+		Type    string  `json:"type"`
+		Entries []entry `json:"entries"`
+	} `json:"instructions"`
+}
+
+// parseUsers returns users and the bottom cursor. Cursors starting with "0|"
+// point past the last page, so an empty cursor is returned for them.
+func (timeline *userTimelineV2) parseUsers() ([]*Profile, string) {
 	var cursor string
 	var users []*Profile
-	for _, instruction := range timeline.Data.RetweetersTimeline.Timeline.Instructions {
+	for _, instruction := range timeline.Instructions {
 		for _, entry := range instruction.Entries {
 			if entry.Content.CursorType == "Bottom" {
 				cursor = entry.Content.Value
@@ -235,9 +437,36 @@ func (timeline *retweetersTimelineV2) parseUsers() ([]*Profile, string) {
 			}
 		}
 	}
+	if strings.HasPrefix(cursor, "0|") {
+		cursor = ""
+	}
 	return users, cursor
 }
 
+type retweetersTimelineV2 struct {
+	Data struct {
+		RetweetersTimeline struct {
+			Timeline userTimelineV2 `json:"timeline"`
+		} `json:"retweeters_timeline"`
+	} `json:"data"`
+}
+
+func (timeline *retweetersTimelineV2) parseUsers() ([]*Profile, string) {
+	return timeline.Data.RetweetersTimeline.Timeline.parseUsers()
+}
+
+type favoritersTimelineV2 struct {
+	Data struct {
+		FavoritersTimeline struct {
+			Timeline userTimelineV2 `json:"timeline"`
+		} `json:"favoriters_timeline"`
+	} `json:"data"`
+}
+
+func (timeline *favoritersTimelineV2) parseUsers() ([]*Profile, string) {
+	return timeline.Data.FavoritersTimeline.Timeline.parseUsers()
+}
+
 func (timeline *timelineV2) parseUsers() ([]*Profile, string) {
 	var cursor string
 	var users []*Profile
@@ -274,8 +503,8 @@ func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet,
 	var cursors []*ThreadCursor
 	for _, instruction := range conversation.Data.ThreadedConversationWithInjectionsV2.Instructions {
 		for _, entry := range instruction.Entries {
-			if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" || entry.Content.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
-				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+			if entry.Content.ItemContent.TweetResults.Result.isTweetOrTombstone() {
+				if tweet := entry.Content.ItemContent.TweetResults.Result.parseWithUnavailable(entryTweetID(entry.EntryID)); tweet != nil {
 					if entry.Content.ItemContent.TweetDisplayType == "SelfThread" {
 						tweet.IsSelfThread = true
 					}
@@ -293,8 +522,8 @@ func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet,
 			}
 
 			for _, item := range entry.Content.Items {
-				if item.Item.ItemContent.TweetResults.Result.Typename == "Tweet" || item.Item.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
-					if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+				if item.Item.ItemContent.TweetResults.Result.isTweetOrTombstone() {
+					if tweet := item.Item.ItemContent.TweetResults.Result.parseWithUnavailable(entryTweetID(item.EntryID)); tweet != nil {
 						if item.Item.ItemContent.TweetDisplayType == "SelfThread" {
 							tweet.IsSelfThread = true
 						}
@@ -322,8 +551,8 @@ func (conversation *threadedConversation) parse(focalTweetID string) ([]*Tweet,
 			}
 		}
 		for _, item := range instruction.ModuleItems {
-			if item.Item.ItemContent.TweetResults.Result.Typename == "Tweet" || item.Item.ItemContent.TweetResults.Result.Typename == "TweetWithVisibilityResults" {
-				if tweet := item.Item.ItemContent.TweetResults.Result.parse(); tweet != nil {
+			if item.Item.ItemContent.TweetResults.Result.isTweetOrTombstone() {
+				if tweet := item.Item.ItemContent.TweetResults.Result.parseWithUnavailable(entryTweetID(item.EntryID)); tweet != nil {
 					if item.Item.ItemContent.TweetDisplayType == "SelfThread" {
 						tweet.IsSelfThread = true
 					}
@@ -382,6 +611,6 @@ type tweetResult struct {
 	} `json:"data"`
 }
 
-func (tweetResult *tweetResult) parse() *Tweet {
-	return tweetResult.Data.TweetResult.Result.parse()
+func (tweetResult *tweetResult) parse(id string) *Tweet {
+	return tweetResult.Data.TweetResult.Result.parseWithUnavailable(id)
 }
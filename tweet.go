@@ -2,12 +2,12 @@ package twitterscraper
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/url"
 	"strconv"
-	"strings"
 )
 
 type NewTweet struct {
@@ -26,24 +26,7 @@ type newTweet struct {
 }
 
 func (newTweet *newTweet) parse() *Tweet {
-	var tweet = &newTweet.Data.CreateTweet.TweetResults.Result
-
-	if tweet.NoteTweet.NoteTweetResults.Result.Text != "" {
-		tweet.Legacy.FullText = tweet.NoteTweet.NoteTweetResults.Result.Text
-	}
-	var legacy *legacyTweet = &tweet.Legacy
-	var user *legacyUser = &tweet.Core.UserResults.Result.Legacy
-	tw := parseLegacyTweet(user, legacy)
-	if tw == nil {
-		return nil
-	}
-	if tw.Views == 0 && tweet.Views.Count != "" {
-		tw.Views, _ = strconv.Atoi(tweet.Views.Count)
-	}
-	if tweet.QuotedStatusResult.Result != nil {
-		tw.QuotedStatus = tweet.QuotedStatusResult.Result.parse()
-	}
-	return tw
+	return newTweet.Data.CreateTweet.TweetResults.Result.parse()
 }
 
 func (s *Scraper) CreateTweet(tweet NewTweet) (*Tweet, error) {
@@ -339,6 +322,12 @@ func (s *Scraper) UnlikeTweet(tweetId string) error {
 
 	return nil
 }
+
+// IterTweetRetweeters returns iterator over profiles who retweeted a given tweet.
+func (s *Scraper) IterTweetRetweeters(ctx context.Context, tweetId string, maxUsersNbr int) *ProfileIterator {
+	return s.newProfileIterator(ctx, OperationTweetRetweeters, tweetId, maxUsersNbr, s.GetTweetRetweeters)
+}
+
 func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
 	if maxUsersNbr > 200 {
 		maxUsersNbr = 200
@@ -397,10 +386,78 @@ func (s *Scraper) GetTweetRetweeters(tweetId string, maxUsersNbr int, cursor str
 	}
 
 	users, nextCursor := timeline.parseUsers()
+	return users, nextCursor, nil
+}
+
+// GetTweetLikers returns channel with profiles who liked a given tweet.
+func (s *Scraper) GetTweetLikers(ctx context.Context, tweetID string, maxUsersNbr int) <-chan *ProfileResult {
+	return s.getUserTimeline(ctx, OperationTweetLikers, tweetID, maxUsersNbr, s.FetchTweetLikers)
+}
+
+// IterTweetLikers returns iterator over profiles who liked a given tweet.
+func (s *Scraper) IterTweetLikers(ctx context.Context, tweetID string, maxUsersNbr int) *ProfileIterator {
+	return s.newProfileIterator(ctx, OperationTweetLikers, tweetID, maxUsersNbr, s.FetchTweetLikers)
+}
+
+// FetchTweetLikers gets profiles who liked a given tweet, via the Twitter frontend GraphQL API.
+// Likes are private, so Twitter returns them only to the author of the tweet.
+func (s *Scraper) FetchTweetLikers(tweetID string, maxUsersNbr int, cursor string) ([]*Profile, string, error) {
+	if maxUsersNbr > 200 {
+		maxUsersNbr = 200
+	}
+
+	req, err := s.newRequest("GET", "https://twitter.com/i/api/graphql/LLkw5EcVutJL6y-2gkz22A/Favoriters")
+	if err != nil {
+		return nil, "", err
+	}
+
+	variables := map[string]interface{}{
+		"tweetId":                tweetID,
+		"includePromotedContent": false,
+		"count":                  maxUsersNbr,
+	}
+
+	features := map[string]interface{}{
+		"rweb_tipjar_consumption_enabled":                                         true,
+		"responsive_web_graphql_exclude_directive_enabled":                        true,
+		"verified_phone_label_enabled":                                            false,
+		"creator_subscriptions_tweet_preview_api_enabled":                         true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"communities_web_enable_tweet_community_results_fetch":                    true,
+		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+		"articles_preview_enabled":                                                true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"rweb_video_timestamps_enabled":                                           true,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+
+	query := url.Values{}
+	query.Set("variables", mapToJSONString(variables))
+	query.Set("features", mapToJSONString(features))
+	req.URL.RawQuery = query.Encode()
 
-	if strings.HasPrefix(nextCursor, "0|") {
-		nextCursor = ""
+	var timeline favoritersTimelineV2
+	err = s.RequestAPI(req, &timeline)
+	if err != nil {
+		return nil, "", err
 	}
 
+	users, nextCursor := timeline.parseUsers()
 	return users, nextCursor, nil
 }
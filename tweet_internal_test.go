@@ -0,0 +1,58 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func userTimelineJSON(key string, bottomCursor string) string {
+	return `{"data": {"` + key + `": {"timeline": {"instructions": [
+		{"type": "TimelineClearCache"},
+		{"type": "TimelineAddEntries", "entries": [
+			{"entryId": "user-1", "content": {"entryType": "TimelineTimelineItem", "itemContent": {"itemType": "TimelineUser", "user_results": {"result": {
+				"__typename": "User", "rest_id": "1", "legacy": {"screen_name": "first", "name": "First"}
+			}}}}},
+			{"entryId": "user-2", "content": {"entryType": "TimelineTimelineItem", "itemContent": {"itemType": "TimelineUser", "user_results": {"result": {
+				"__typename": "UserUnavailable", "rest_id": "2"
+			}}}}},
+			{"entryId": "user-3", "content": {"entryType": "TimelineTimelineItem", "itemContent": {"itemType": "TimelineUser", "user_results": {"result": {
+				"__typename": "User", "rest_id": "3", "legacy": {"screen_name": "third", "name": "Third"}
+			}}}}},
+			{"entryId": "cursor-top-1", "content": {"entryType": "TimelineTimelineCursor", "cursorType": "Top", "value": "-1|1"}},
+			{"entryId": "cursor-bottom-0", "content": {"entryType": "TimelineTimelineCursor", "cursorType": "Bottom", "value": "` + bottomCursor + `"}}
+		]}
+	]}}}}`
+}
+
+func TestUserTimelineParseUsers(t *testing.T) {
+	tests := []struct {
+		bottomCursor   string
+		expectedCursor string
+	}{
+		{"1795093925958926336|1795135282714890239", "1795093925958926336|1795135282714890239"},
+		{"0|1795135282714890238", ""},
+	}
+	for _, test := range tests {
+		var favoriters favoritersTimelineV2
+		if err := json.Unmarshal([]byte(userTimelineJSON("favoriters_timeline", test.bottomCursor)), &favoriters); err != nil {
+			t.Fatal(err)
+		}
+		var retweeters retweetersTimelineV2
+		if err := json.Unmarshal([]byte(userTimelineJSON("retweeters_timeline", test.bottomCursor)), &retweeters); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, parseUsers := range map[string]func() ([]*Profile, string){
+			"favoriters": favoriters.parseUsers,
+			"retweeters": retweeters.parseUsers,
+		} {
+			users, cursor := parseUsers()
+			if len(users) != 2 || users[0].Username != "first" || users[1].Username != "third" {
+				t.Errorf("%s: expected users first and third without unavailable one, got: %v", name, users)
+			}
+			if cursor != test.expectedCursor {
+				t.Errorf("%s: expected cursor %q for %q, got %q", name, test.expectedCursor, test.bottomCursor, cursor)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package twitterscraper_test
 
 import (
+	"context"
 	"testing"
 
 	twitterscraper "github.com/imperatrona/twitter-scraper"
@@ -124,3 +125,57 @@ func TestGetTweetRetweeters(t *testing.T) {
 		t.Error("0 tweet retweeters")
 	}
 }
+
+func TestFetchTweetQuotes(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	tweetId := "1792634158977568997"
+
+	quotes, _, err := testScraper.FetchTweetQuotes(tweetId, 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotes) == 0 {
+		t.Fatal("0 tweet quotes")
+	}
+	for _, quote := range quotes {
+		if quote.QuotedStatus == nil || quote.QuotedStatus.ID != tweetId {
+			t.Errorf("Expected QuotedStatus of %s is the tweet %s", quote.ID, tweetId)
+		}
+	}
+}
+
+func TestGetTweetQuotes(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	for tweet := range testScraper.GetTweetQuotes(context.Background(), "1792634158977568997", 30) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		count++
+	}
+	if count == 0 {
+		t.Error("0 tweet quotes")
+	}
+}
+
+func TestFetchTweetLikers(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	tweetId := "1792634158977568997"
+
+	// likes are private, only the author of the tweet gets the profiles
+	likers, _, err := testScraper.FetchTweetLikers(tweetId, 20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, liker := range likers {
+		if liker.UserID == "" || liker.Username == "" {
+			t.Errorf("Expected liker has UserID and Username, got %+v", liker)
+		}
+	}
+}
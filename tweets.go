@@ -9,12 +9,22 @@ import (
 
 // GetTweets returns channel with tweets for a given user.
 func (s *Scraper) GetTweets(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweets)
+	return s.getTweetTimeline(ctx, OperationTweets, user, maxTweetsNbr, s.FetchTweets)
 }
 
 // GetTweetsAndReplies returns channel with tweets and replies for a given user.
 func (s *Scraper) GetTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, user, maxTweetsNbr, s.FetchTweetsAndReplies)
+	return s.getTweetTimeline(ctx, OperationTweetsAndReplies, user, maxTweetsNbr, s.FetchTweetsAndReplies)
+}
+
+// IterTweets returns iterator over tweets for a given user.
+func (s *Scraper) IterTweets(ctx context.Context, user string, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationTweets, user, maxTweetsNbr, s.FetchTweets)
+}
+
+// IterTweetsAndReplies returns iterator over tweets and replies for a given user.
+func (s *Scraper) IterTweetsAndReplies(ctx context.Context, user string, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationTweetsAndReplies, user, maxTweetsNbr, s.FetchTweetsAndReplies)
 }
 
 // FetchTweets gets tweets for a given user, via the Twitter frontend API.
@@ -197,6 +207,7 @@ func (s *Scraper) FetchTweetsByUserIDLegacy(userID string, maxTweetsNbr int, cur
 }
 
 // GetTweet get a single tweet by ID.
+// Deleted, withheld and other unavailable tweets are returned with only ID and Unavailable set.
 func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 	if s.isOpenAccount {
 		req, err := s.newRequest("GET", "https://api.twitter.com/2/timeline/conversation/"+id+".json")
@@ -245,6 +256,8 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
 			"view_counts_everywhere_api_enabled":                                      true,
 			"longform_notetweets_consumption_enabled":                                 true,
+			"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+			"articles_preview_enabled":                                                true,
 			"tweet_awards_web_tipping_enabled":                                        false,
 			"freedom_of_speech_not_reach_fetch_enabled":                               true,
 			"standardized_nudges_misinfo":                                             true,
@@ -254,9 +267,15 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			"responsive_web_enhance_cards_enabled":                                    false,
 		}
 
+		fieldToggles := map[string]interface{}{
+			"withArticleRichContentState": true,
+			"withArticlePlainText":        false,
+		}
+
 		query := url.Values{}
 		query.Set("variables", mapToJSONString(variables))
 		query.Set("features", mapToJSONString(features))
+		query.Set("fieldToggles", mapToJSONString(fieldToggles))
 		req.URL.RawQuery = query.Encode()
 
 		var conversation threadedConversation
@@ -348,8 +367,9 @@ func (s *Scraper) GetTweet(id string) (*Tweet, error) {
 			return nil, err
 		}
 
-		tweet := result.parse()
-		return tweet, nil
+		if tweet := result.parse(id); tweet != nil {
+			return tweet, nil
+		}
 	}
 	return nil, fmt.Errorf("tweet with ID %s not found", id)
 }
@@ -364,6 +384,7 @@ type homeEntry struct {
 			TweetResults struct {
 				Result result `json:"result"`
 			} `json:"tweet_results"`
+			PromotedMetadata *struct{} `json:"promotedMetadata"`
 		} `json:"itemContent"`
 		Cursor     string `json:"value"`
 		CursorType string `json:"cursorType"`
@@ -398,6 +419,7 @@ func (timeline *homeTimeline) parseTweets() ([]*Tweet, string) {
 				cursor = entry.Content.Cursor
 			} else if entry.Content.ItemContent.TweetResults.Result.Typename == "Tweet" {
 				if tweet := entry.Content.ItemContent.TweetResults.Result.parse(); tweet != nil {
+					tweet.EntryType = parseEntryType(entry.EntryId, entry.Content.ItemContent.PromotedMetadata != nil)
 					tweets = append(tweets, tweet)
 				}
 			}
@@ -408,7 +430,12 @@ func (timeline *homeTimeline) parseTweets() ([]*Tweet, string) {
 
 // GetHomeTweets returns channel with tweets from home timeline
 func (s *Scraper) GetHomeTweets(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchHomeTweets)
+	return s.getTweetTimeline(ctx, OperationHomeTweets, "", maxTweetsNbr, s.fetchHomeTweets)
+}
+
+// IterHomeTweets returns iterator over tweets from home timeline
+func (s *Scraper) IterHomeTweets(ctx context.Context, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationHomeTweets, "", maxTweetsNbr, s.fetchHomeTweets)
 }
 
 func (s *Scraper) FetchHomeTweets(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
@@ -483,7 +510,12 @@ func (s *Scraper) fetchHomeTweets(_ string, maxTweetsNbr int, cursor string) ([]
 
 // GetForYouTweets returns channel with tweets from for you timeline
 func (s *Scraper) GetForYouTweets(ctx context.Context, maxTweetsNbr int) <-chan *TweetResult {
-	return getTweetTimeline(ctx, "", maxTweetsNbr, s.fetchForYouTweets)
+	return s.getTweetTimeline(ctx, OperationForYouTweets, "", maxTweetsNbr, s.fetchForYouTweets)
+}
+
+// IterForYouTweets returns iterator over tweets from for you timeline
+func (s *Scraper) IterForYouTweets(ctx context.Context, maxTweetsNbr int) *TweetIterator {
+	return s.newTweetIterator(ctx, OperationForYouTweets, "", maxTweetsNbr, s.fetchForYouTweets)
 }
 
 func (s *Scraper) FetchForYouTweets(maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
@@ -2,7 +2,9 @@ package twitterscraper_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -18,6 +20,34 @@ var cmpOptions = cmp.Options{
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "IsSelfThread"),
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Thread"),
 	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "TimeParsed"),
+
+	// notes are added and removed as they are rated
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "CommunityNote"),
+
+	// editable until time and counts change, checked by TestGetTweetEditHistory
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "EditControl"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "PreviousCounts"),
+
+	// profile counts change, checked by TestTweetAuthor
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Author"),
+
+	// counts and account flags change, checked by TestTweetMetadata
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Bookmarks"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Quotes"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "HasSuperFollower"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "IsBlueVerified"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "IsTranslatable"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Lang"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "LimitedActions"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "PossiblySensitive"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "ReplyPolicy"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Source"),
+
+	// checked by TestTweetEntitiesAndMedia and TestTweetPollCard
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Card"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "DisplayTextRange"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Entities"),
+	cmpopts.IgnoreFields(twitterscraper.Tweet{}, "Media"),
 }
 
 func TestGetTweets(t *testing.T) {
@@ -74,20 +104,24 @@ func TestGetTweets(t *testing.T) {
 	}
 }
 
-func assertGetTweet(t *testing.T, expectedTweet *twitterscraper.Tweet) {
+func assertGetTweet(t *testing.T, expectedTweet *twitterscraper.Tweet, expectedHTML string) {
 	// to get tweet as struct fmt.Printf("%#v", actualTweet)
 	actualTweet, err := testScraper.GetTweet(expectedTweet.ID)
 	if err != nil {
 		t.Error(err)
-	} else if diff := cmp.Diff(expectedTweet, actualTweet, cmpOptions...); diff != "" {
+		return
+	}
+	if diff := cmp.Diff(expectedTweet, actualTweet, cmpOptions...); diff != "" {
 		t.Error("Resulting tweet does not match the sample", diff)
 	}
+	if html := actualTweet.HTML(); html != expectedHTML {
+		t.Errorf("Expected HTML %q, got %q", expectedHTML, html)
+	}
 }
 
 func TestGetTweetWithVideo(t *testing.T) {
 	expectedTweet := twitterscraper.Tweet{
 		ConversationID: "1697304622749086011",
-		HTML:           "on iOS &amp; Android, you can now swipe to reply when you slide into their DMs <br><a href=\"https://t.co/evuWpMfBxQ\"><img src=\"https://pbs.twimg.com/amplify_video_thumb/1697304568550330368/img/BUlESpef6FmWV_j2.jpg\"/></a>",
 		ID:             "1697304622749086011",
 		Name:           "X",
 		PermanentURL:   "https://twitter.com/X/status/1697304622749086011",
@@ -105,13 +139,12 @@ func TestGetTweetWithVideo(t *testing.T) {
 			},
 		},
 	}
-	assertGetTweet(t, &expectedTweet)
+	assertGetTweet(t, &expectedTweet, "on iOS &amp; Android, you can now swipe to reply when you slide into their DMs <br><a href=\"https://t.co/evuWpMfBxQ\"><img src=\"https://pbs.twimg.com/amplify_video_thumb/1697304568550330368/img/BUlESpef6FmWV_j2.jpg\"/></a>")
 }
 
 func TestGetTweetWithMultiplePhotos(t *testing.T) {
 	expectedTweet := twitterscraper.Tweet{
 		ConversationID: "1577677328968204291",
-		HTML:           "More ways to discover videos on Twitter are here!<br><br>Now on iOS, videos on your timeline will open in our full screen immersive video player, where you can swipe up to keep discovering more content. <br><a href=\"https://t.co/XI2vM8DKXA\"><img src=\"https://pbs.twimg.com/media/FeUJKdnXEAEFe2j.jpg\"/></a><br><img src=\"https://pbs.twimg.com/media/FeUJKuxXEAAa6t7.jpg\"/>",
 		ID:             "1577677328968204291",
 		Name:           "Support",
 		PermanentURL:   "https://twitter.com/Support/status/1577677328968204291",
@@ -130,7 +163,7 @@ func TestGetTweetWithMultiplePhotos(t *testing.T) {
 		UserID:    "17874544",
 		Username:  "Support",
 	}
-	assertGetTweet(t, &expectedTweet)
+	assertGetTweet(t, &expectedTweet, "More ways to discover videos on Twitter are here!<br><br>Now on iOS, videos on your timeline will open in our full screen immersive video player, where you can swipe up to keep discovering more content. <br><a href=\"https://t.co/XI2vM8DKXA\"><img src=\"https://pbs.twimg.com/media/FeUJKdnXEAEFe2j.jpg\"/></a><br><img src=\"https://pbs.twimg.com/media/FeUJKuxXEAAa6t7.jpg\"/>")
 }
 
 func TestGetTweetWithGIF(t *testing.T) {
@@ -143,7 +176,6 @@ func TestGetTweetWithGIF(t *testing.T) {
 				URL:     "https://video.twimg.com/tweet_video/FQ9eXEhXEAA-haj.mp4",
 			},
 		},
-		HTML:         "Video captions or no captions, it’s now easier to choose for some of you on iOS, and soon on Android.<br><br>On videos that have captions available, we’re testing the option to turn captions off/on with a new “CC” button. <br><a href=\"https://t.co/Q2Q2Wmr78U\"><img src=\"https://pbs.twimg.com/tweet_video_thumb/FQ9eXEhXEAA-haj.jpg\"/></a>",
 		ID:           "1517535384833605632",
 		Name:         "Support",
 		PermanentURL: "https://twitter.com/Support/status/1517535384833605632",
@@ -152,7 +184,7 @@ func TestGetTweetWithGIF(t *testing.T) {
 		UserID:       "17874544",
 		Username:     "Support",
 	}
-	assertGetTweet(t, &expectedTweet)
+	assertGetTweet(t, &expectedTweet, "Video captions or no captions, it’s now easier to choose for some of you on iOS, and soon on Android.<br><br>On videos that have captions available, we’re testing the option to turn captions off/on with a new “CC” button. <br><a href=\"https://t.co/Q2Q2Wmr78U\"><img src=\"https://pbs.twimg.com/tweet_video_thumb/FQ9eXEhXEAA-haj.jpg\"/></a>")
 }
 
 func TestGetTweetWithPhotoAndGIF(t *testing.T) {
@@ -165,7 +197,6 @@ func TestGetTweetWithPhotoAndGIF(t *testing.T) {
 				URL:     "https://video.twimg.com/tweet_video/FfibjDnWIBIt5fn.mp4",
 			},
 		},
-		HTML:         "“we need to talk” <br><br>irl vs on Spaces <br><a href=\"https://t.co/hrflPpbpif\"><img src=\"https://pbs.twimg.com/tweet_video_thumb/FfibjDnWIBIt5fn.jpg\"/></a><br><img src=\"https://pbs.twimg.com/media/FfibjDwWIAwvbtJ.jpg\"/>",
 		ID:           "1583186305722507265",
 		Name:         "Spaces",
 		PermanentURL: "https://twitter.com/XSpaces/status/1583186305722507265",
@@ -175,7 +206,7 @@ func TestGetTweetWithPhotoAndGIF(t *testing.T) {
 		UserID:       "1065249714214457345",
 		Username:     "XSpaces",
 	}
-	assertGetTweet(t, &expectedTweet)
+	assertGetTweet(t, &expectedTweet, "“we need to talk” <br><br>irl vs on Spaces <br><a href=\"https://t.co/hrflPpbpif\"><img src=\"https://pbs.twimg.com/tweet_video_thumb/FfibjDnWIBIt5fn.jpg\"/></a><br><img src=\"https://pbs.twimg.com/media/FfibjDwWIAwvbtJ.jpg\"/>")
 }
 
 func TestTweetMentions(t *testing.T) {
@@ -194,10 +225,76 @@ func TestTweetMentions(t *testing.T) {
 	}
 }
 
+func TestTweetEntitiesAndMedia(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1697304622749086011")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweet.Media) != 1 {
+		t.Fatalf("Expected media count=%v, got: %v", 1, len(tweet.Media))
+	}
+	media := tweet.Media[0]
+	if media.Type != "video" || media.ID != "1697304568550330368" {
+		t.Errorf("Expected video 1697304568550330368, got: %s %s", media.Type, media.ID)
+	}
+	if media.Width == 0 || media.Height == 0 || media.Duration == 0 {
+		t.Errorf("Expected video dimensions and duration, got: %dx%d %v", media.Width, media.Height, media.Duration)
+	}
+	if len(media.Variants) < 2 {
+		t.Errorf("Expected all video variants, got: %v", len(media.Variants))
+	}
+	if media.URL != "https://t.co/evuWpMfBxQ" {
+		t.Errorf("Expected media URL=%v, got: %v", "https://t.co/evuWpMfBxQ", media.URL)
+	}
+	if tweet.DisplayTextRange[1] == 0 {
+		t.Error("Expected DisplayTextRange is set")
+	}
+
+	tweet, err = testScraper.GetTweet("1237110546383724547")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweet.Entities.URLs) != 1 {
+		t.Fatalf("Expected URL entities count=%v, got: %v", 1, len(tweet.Entities.URLs))
+	}
+	url := tweet.Entities.URLs[0]
+	if url.URL != "https://t.co/YdaeDYmPAU" || url.ExpandedURL != "https://youtu.be/ytfCdqWhmdg" || url.DisplayURL == "" {
+		t.Errorf("Unexpected URL entity: %#v", url)
+	}
+	if url.Indices[1]-url.Indices[0] != len(url.URL) {
+		t.Errorf("Expected URL indices to span %v, got: %v", len(url.URL), url.Indices)
+	}
+}
+
+func TestTweetPollCard(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1604617643973124097")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Card == nil || tweet.Card.Poll == nil {
+		t.Fatalf("Expected tweet has poll card, got: %#v", tweet.Card)
+	}
+	poll := tweet.Card.Poll
+	if len(poll.Choices) != 2 || poll.Choices[0].Label != "Yes" || poll.Choices[1].Label != "No" {
+		t.Errorf("Unexpected poll choices: %#v", poll.Choices)
+	}
+	if poll.Choices[0].Count == 0 || poll.Choices[1].Count == 0 {
+		t.Error("Expected poll counts are set")
+	}
+	if !poll.Final || poll.IsOpen() {
+		t.Error("Expected poll is final and closed")
+	}
+	if poll.EndTime.IsZero() {
+		t.Error("Expected poll EndTime is set")
+	}
+	if len(tweet.Card.Values) == 0 {
+		t.Error("Expected card raw values are set")
+	}
+}
+
 func TestQuotedAndReply(t *testing.T) {
 	sample := &twitterscraper.Tweet{
 		ConversationID: "1237110546383724547",
-		HTML:           "The Easiest Problem Everyone Gets Wrong <br><br>[new video] --&gt; <a href=\"https://youtu.be/ytfCdqWhmdg\">https://t.co/YdaeDYmPAU</a> <br><a href=\"https://t.co/iKu4Xs6o2V\"><img src=\"https://pbs.twimg.com/media/ESsZa9AXgAIAYnF.jpg\"/></a>",
 		ID:             "1237110546383724547",
 		Likes:          485,
 		Name:           "Vsauce2",
@@ -224,6 +321,9 @@ func TestQuotedAndReply(t *testing.T) {
 		if diff := cmp.Diff(sample, tweet.QuotedStatus, cmpOptions...); diff != "" {
 			t.Error("Resulting quote does not match the sample", diff)
 		}
+		if html := tweet.QuotedStatus.HTML(); html != "The Easiest Problem Everyone Gets Wrong <br><br>[new video] --&gt; <a href=\"https://youtu.be/ytfCdqWhmdg\">https://t.co/YdaeDYmPAU</a> <br><a href=\"https://t.co/iKu4Xs6o2V\"><img src=\"https://pbs.twimg.com/media/ESsZa9AXgAIAYnF.jpg\"/></a>" {
+			t.Errorf("Resulting quote HTML does not match the sample: %q", html)
+		}
 	}
 	tweet, err = testScraper.GetTweet("1237111868445134850")
 	if err != nil {
@@ -241,7 +341,6 @@ func TestQuotedAndReply(t *testing.T) {
 func TestRetweet(t *testing.T) {
 	sample := &twitterscraper.Tweet{
 		ConversationID: "1758837061786779942",
-		HTML:           "no ads, just bangers<br><br>aka your For You feed with Premium+<br><br>subscribe here → <a href=\"https://x.com/i/premium_sign_up\">https://t.co/APTO1t7kMk</a>",
 		ID:             "1758837061786779942",
 		URLs:           []string{"https://x.com/i/premium_sign_up"},
 		IsSelfThread:   false,
@@ -262,12 +361,14 @@ func TestRetweet(t *testing.T) {
 		if diff := cmp.Diff(sample, tweet.RetweetedStatus, cmpOptions...); diff != "" {
 			t.Error("Resulting retweet does not match the sample", diff)
 		}
+		if html := tweet.RetweetedStatus.HTML(); html != "no ads, just bangers<br><br>aka your For You feed with Premium+<br><br>subscribe here → <a href=\"https://x.com/i/premium_sign_up\">https://t.co/APTO1t7kMk</a>" {
+			t.Errorf("Resulting retweet HTML does not match the sample: %q", html)
+		}
 	}
 }
 
 func TestTweetViews(t *testing.T) {
 	sample := &twitterscraper.Tweet{
-		HTML:         "Replies and likes don’t tell the whole story. We’re making it easier to tell *just* how many people have seen your Tweets with the addition of view counts, shown right next to likes. Now on iOS and Android, web coming soon.",
 		ID:           "1606055187348688896",
 		Likes:        2839,
 		Name:         "Support",
@@ -467,3 +568,161 @@ func TestGetTweetsAndReplies(t *testing.T) {
 		t.Errorf("Got %d tweets", len(tweets))
 	}
 }
+
+func TestIterTweets(t *testing.T) {
+	count := 0
+	maxTweetsNbr := 40
+	dupcheck := make(map[string]bool)
+	it := testScraper.IterTweets(context.Background(), "x", maxTweetsNbr)
+	defer it.Close()
+	for it.Next() {
+		tweet := it.Tweet()
+		count++
+		if tweet.ID == "" {
+			t.Error("Expected tweet ID is empty")
+		} else if dupcheck[tweet.ID] {
+			t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+		} else {
+			dupcheck[tweet.ID] = true
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+	if count != maxTweetsNbr {
+		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
+	}
+	if it.Cursor() == "" {
+		t.Error("Expected cursor is empty")
+	}
+}
+
+func TestIterTweetsWithOptions(t *testing.T) {
+	since := time.Now().AddDate(0, -6, 0)
+	until := time.Now().AddDate(0, -1, 0)
+	it := testScraper.IterTweets(context.Background(), "x", 100).WithOptions(twitterscraper.TimelineOptions{
+		Since: since,
+		Until: until,
+	})
+	defer it.Close()
+	for it.Next() {
+		tweet := it.Tweet()
+		if tweet.TimeParsed.Before(since) {
+			t.Errorf("Expected tweet %s created after %v, got: %v", tweet.ID, since, tweet.TimeParsed)
+		}
+		if tweet.TimeParsed.After(until) {
+			t.Errorf("Expected tweet %s created before %v, got: %v", tweet.ID, until, tweet.TimeParsed)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIterForYouTweetsOrganicOnly(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	count := 0
+	maxTweetsNbr := 60
+	dupcheck := make(map[string]bool)
+	it := testScraper.IterForYouTweets(context.Background(), maxTweetsNbr).WithOptions(twitterscraper.TimelineOptions{
+		OrganicOnly:  true,
+		DedupeWindow: 20,
+	})
+	defer it.Close()
+	for it.Next() {
+		tweet := it.Tweet()
+		count++
+		if tweet.EntryType != twitterscraper.EntryOrganic {
+			t.Errorf("Expected organic tweet %s, got entry type %v", tweet.ID, tweet.EntryType)
+		}
+		if dupcheck[tweet.ID] {
+			t.Errorf("Detect duplicated tweet ID: %s", tweet.ID)
+		}
+		dupcheck[tweet.ID] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Error(err)
+	}
+	if count != maxTweetsNbr {
+		t.Errorf("Expected tweets count=%v, got: %v", maxTweetsNbr, count)
+	}
+}
+
+func TestGetTweetEditHistory(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	versions, err := testScraper.GetTweetEditHistory("1328684389388185600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || versions[0].ID != "1328684389388185600" {
+		t.Fatalf("Expected tweet which was never edited is the only version, got %d versions", len(versions))
+	}
+	if versions[0].EditControl.IsEdited() {
+		t.Error("Expected tweet is not edited")
+	}
+}
+
+func TestTweetMetadata(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1697304622749086011")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Lang != "en" {
+		t.Errorf("Expected Lang en, got %q", tweet.Lang)
+	}
+	if tweet.Source == "" || strings.Contains(tweet.Source, "<") {
+		t.Errorf("Expected Source is a client name, got %q", tweet.Source)
+	}
+	if tweet.Quotes == 0 {
+		t.Error("Expected Quotes is set")
+	}
+	if tweet.PossiblySensitive {
+		t.Error("Expected tweet is not possibly sensitive")
+	}
+}
+
+func TestTweetAuthor(t *testing.T) {
+	tweet, err := testScraper.GetTweet("1697304622749086011")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tweet.Author == nil {
+		t.Fatal("Expected Author is set")
+	}
+	if tweet.Author.UserID != "783214" || tweet.Author.Username != "X" {
+		t.Errorf("Expected author X with ID 783214, got @%s with ID %s", tweet.Author.Username, tweet.Author.UserID)
+	}
+	if tweet.Author.FollowersCount == 0 {
+		t.Error("Expected author FollowersCount is greater than zero")
+	}
+}
+
+func TestTweetCreatedAtFromID(t *testing.T) {
+	tweet := twitterscraper.Tweet{ID: "1697304622749086011"}
+	expected := time.Date(2023, 8, 31, 17, 45, 31, 115*int(time.Millisecond), time.UTC)
+	if tm := tweet.CreatedAtFromID(); !tm.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, tm)
+	}
+	if tm := (&twitterscraper.Tweet{ID: "20"}).CreatedAtFromID(); !tm.IsZero() {
+		t.Errorf("Expected zero time for a sequential ID, got %v", tm)
+	}
+}
+
+func TestTweetHTML(t *testing.T) {
+	tweet := twitterscraper.Tweet{
+		Text:     "#golang by @x\nhttps://t.co/AAAAAAAAAA https://t.co/BBBBBBBBBB",
+		Entities: twitterscraper.Entities{URLs: []twitterscraper.URLEntity{{URL: "https://t.co/AAAAAAAAAA", ExpandedURL: "https://go.dev"}}},
+		Media:    []twitterscraper.TweetMedia{{URL: "https://t.co/BBBBBBBBBB", MediaURL: "https://pbs.twimg.com/media/1.jpg"}},
+		Photos:   []twitterscraper.Photo{{URL: "https://pbs.twimg.com/media/1.jpg"}, {URL: "https://pbs.twimg.com/media/2.jpg"}},
+	}
+	expected := `<a href="https://twitter.com/hashtag/golang">#golang</a> by <a href="https://twitter.com/x">@x</a><br>` +
+		`<a href="https://go.dev">https://t.co/AAAAAAAAAA</a> <br><a href="https://t.co/BBBBBBBBBB"><img src="https://pbs.twimg.com/media/1.jpg"/></a>` +
+		`<br><img src="https://pbs.twimg.com/media/2.jpg"/>`
+	if html := tweet.HTML(); html != expected {
+		t.Errorf("Expected %s, got %s", expected, html)
+	}
+}
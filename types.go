@@ -1,80 +1,420 @@
 package twitterscraper
 
-import "time"
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
 
 type (
 	// Mention type.
 	Mention struct {
-		ID       string
-		Username string
-		Name     string
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
 	}
 
 	// Photo type.
 	Photo struct {
-		ID  string
-		URL string
+		ID  string `json:"id"`
+		URL string `json:"url"`
 	}
 
 	// Video type.
 	Video struct {
-		ID      string
-		Preview string
-		URL     string
-		HLSURL  string
+		ID      string `json:"id"`
+		Preview string `json:"preview"`
+		URL     string `json:"url"`
+		HLSURL  string `json:"hls_url"`
 	}
 
 	// GIF type.
 	GIF struct {
-		ID      string
-		Preview string
-		URL     string
+		ID      string `json:"id"`
+		Preview string `json:"preview"`
+		URL     string `json:"url"`
+	}
+
+	// TextEntity is a hashtag or cashtag.
+	TextEntity struct {
+		Text string `json:"text"`
+		// Indices are the start and end offsets in the tweet text, see Entities.
+		Indices [2]int `json:"indices"`
+	}
+
+	// MentionEntity is a mentioned user.
+	MentionEntity struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+		Indices  [2]int `json:"indices"`
+	}
+
+	// URLEntity is a link shortened to t.co.
+	URLEntity struct {
+		URL         string `json:"url"`
+		DisplayURL  string `json:"display_url"`
+		ExpandedURL string `json:"expanded_url"`
+		// UnwoundURL is the final URL after redirects, when Twitter provides it.
+		UnwoundURL string `json:"unwound_url"`
+		Indices    [2]int `json:"indices"`
+	}
+
+	// Entities of a tweet with positions in its text. Indices of entities and media and
+	// Tweet.DisplayTextRange are counted in Unicode code points of the unescaped text,
+	// so &amp; in Tweet.Text takes one position. Note tweet rich text and inline media
+	// are counted the same way. Only article blocks differ: offsets of ArticleStyle and
+	// ArticleEntity are counted in UTF-16 code units of ArticleBlock.Text, as Twitter
+	// sends them.
+	Entities struct {
+		Hashtags []TextEntity    `json:"hashtags,omitempty"`
+		Cashtags []TextEntity    `json:"cashtags,omitempty"`
+		Mentions []MentionEntity `json:"mentions,omitempty"`
+		URLs     []URLEntity     `json:"urls,omitempty"`
+	}
+
+	// VideoVariant is a single encoding of a video or GIF.
+	VideoVariant struct {
+		URL         string `json:"url"`
+		ContentType string `json:"content_type"`
+		Bitrate     int    `json:"bitrate"`
+	}
+
+	// TweetMedia is a media attached to a tweet with all details Twitter provides.
+	TweetMedia struct {
+		ID       string `json:"id"`
+		MediaKey string `json:"media_key"`
+		// Type is photo, video or animated_gif.
+		Type        string         `json:"type"`
+		MediaURL    string         `json:"media_url"`
+		URL         string         `json:"url"`
+		DisplayURL  string         `json:"display_url"`
+		ExpandedURL string         `json:"expanded_url"`
+		Indices     [2]int         `json:"indices"`
+		Width       int            `json:"width"`
+		Height      int            `json:"height"`
+		AspectRatio [2]int         `json:"aspect_ratio"`
+		Duration    time.Duration  `json:"duration_ns"`
+		Variants    []VideoVariant `json:"variants,omitempty"`
+		AltText     string         `json:"alt_text"`
+		// Availability is Available or Unavailable, with a reason for the latter.
+		Availability      string   `json:"availability"`
+		UnavailableReason string   `json:"unavailable_reason"`
+		SensitiveWarnings []string `json:"sensitive_warnings,omitempty"`
+	}
+
+	// CardImage is an image of a card.
+	CardImage struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	}
+
+	// CardValue is a raw binding value of a card.
+	CardValue struct {
+		// Type is STRING, BOOLEAN, IMAGE, IMAGE_COLOR or USER.
+		Type    string     `json:"type"`
+		String  string     `json:"string"`
+		Boolean bool       `json:"boolean"`
+		Image   *CardImage `json:"image,omitempty"`
+		UserID  string     `json:"user_id"`
+	}
+
+	// PollChoice is an option of a poll.
+	PollChoice struct {
+		Label string `json:"label"`
+		Count int    `json:"count"`
+	}
+
+	// Poll card.
+	Poll struct {
+		Choices         []PollChoice `json:"choices,omitempty"`
+		DurationMinutes int          `json:"duration_minutes"`
+		EndTime         time.Time    `json:"end_time"`
+		LastUpdated     time.Time    `json:"last_updated"`
+		// Final is set when voting ended and counts won't change.
+		Final bool `json:"final"`
+	}
+
+	// LinkPreview is a summary card of a link.
+	LinkPreview struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Domain      string `json:"domain"`
+		// URL is the destination of the link.
+		URL   string     `json:"url"`
+		Image *CardImage `json:"image,omitempty"`
+	}
+
+	// Player card with an embedded media player.
+	Player struct {
+		Title       string     `json:"title"`
+		Description string     `json:"description"`
+		URL         string     `json:"url"`
+		StreamURL   string     `json:"stream_url"`
+		Width       int        `json:"width"`
+		Height      int        `json:"height"`
+		Image       *CardImage `json:"image,omitempty"`
+	}
+
+	// AppCard promotes a mobile app.
+	AppCard struct {
+		Name       string     `json:"name"`
+		AppID      string     `json:"app_id"`
+		Category   string     `json:"category"`
+		StarRating float64    `json:"star_rating"`
+		Ratings    int        `json:"ratings"`
+		Image      *CardImage `json:"image,omitempty"`
+	}
+
+	// Card attached to a tweet. One typed variant is set depending on Name,
+	// Values always hold all binding values so unknown cards can be read too.
+	Card struct {
+		ID          string               `json:"id"`
+		Name        string               `json:"name"`
+		URL         string               `json:"url"`
+		Poll        *Poll                `json:"poll,omitempty"`
+		LinkPreview *LinkPreview         `json:"link_preview,omitempty"`
+		Player      *Player              `json:"player,omitempty"`
+		App         *AppCard             `json:"app,omitempty"`
+		Values      map[string]CardValue `json:"values,omitempty"`
+	}
+
+	// CommunityNote is a Community Notes (Birdwatch) note adding context to a tweet.
+	CommunityNote struct {
+		ID      string `json:"id"`
+		TweetID string `json:"tweet_id"`
+		Title   string `json:"title"`
+		Text    string `json:"text"`
+		// Entities are links in Text.
+		Entities []URLEntity `json:"entities,omitempty"`
+		// RatingStatus is NoteRatedHelpful, NoteRatedNotHelpful or NoteNeedsMoreRating,
+		// empty if unknown as for most notes shown under a tweet.
+		RatingStatus   string    `json:"rating_status"`
+		Classification string    `json:"classification"`
+		MisleadingTags []string  `json:"misleading_tags,omitempty"`
+		HelpfulTags    []string  `json:"helpful_tags,omitempty"`
+		Language       string    `json:"language"`
+		CreatedAt      time.Time `json:"created_at"`
+		URL            string    `json:"url"`
+	}
+
+	// RichTextTag formats a range of a note tweet text.
+	RichTextTag struct {
+		// Indices are the start and end offsets in the tweet text, see Entities.
+		Indices [2]int `json:"indices"`
+		// Types are Bold or Italic.
+		Types []string `json:"types,omitempty"`
+	}
+
+	// InlineMedia is a media placed inside a note tweet text.
+	InlineMedia struct {
+		MediaID string `json:"media_id"`
+		// Index is the offset in the tweet text, see Entities.
+		Index int `json:"index"`
+	}
+
+	// NoteTweet holds formatting of a tweet longer than 280 characters,
+	// its full text and entities are set on the tweet itself.
+	NoteTweet struct {
+		ID          string        `json:"id"`
+		RichText    []RichTextTag `json:"rich_text,omitempty"`
+		InlineMedia []InlineMedia `json:"inline_media,omitempty"`
+	}
+
+	// ArticleImage is a cover or embedded image of an article.
+	ArticleImage struct {
+		ID       string `json:"id"`
+		MediaKey string `json:"media_key"`
+		URL      string `json:"url"`
+		Width    int    `json:"width"`
+		Height   int    `json:"height"`
+	}
+
+	// ArticleStyle is an inline style of a block range: Bold, Italic or Strikethrough.
+	ArticleStyle struct {
+		Offset int    `json:"offset"`
+		Length int    `json:"length"`
+		Style  string `json:"style"`
+	}
+
+	// ArticleEntity is a link, media, tweet or divider in an article block.
+	ArticleEntity struct {
+		Offset int `json:"offset"`
+		Length int `json:"length"`
+		// Type is LINK, MEDIA, TWEET or DIVIDER.
+		Type     string   `json:"type"`
+		URL      string   `json:"url"`
+		TweetID  string   `json:"tweet_id"`
+		MediaIDs []string `json:"media_ids,omitempty"`
+	}
+
+	// ArticleBlock is a paragraph of an article. Type is unstyled, header-one, header-two,
+	// unordered-list-item, ordered-list-item, blockquote, code-block or atomic for embeds.
+	// Offsets of styles and entities are counted in UTF-16 code units, see Entities.
+	ArticleBlock struct {
+		Type     string          `json:"type"`
+		Text     string          `json:"text"`
+		Styles   []ArticleStyle  `json:"styles,omitempty"`
+		Entities []ArticleEntity `json:"entities,omitempty"`
+	}
+
+	// Article is a long-form post attached to a tweet.
+	Article struct {
+		ID          string         `json:"id"`
+		Title       string         `json:"title"`
+		PreviewText string         `json:"preview_text"`
+		CoverImage  *ArticleImage  `json:"cover_image,omitempty"`
+		Blocks      []ArticleBlock `json:"blocks,omitempty"`
+		Media       []ArticleImage `json:"media,omitempty"`
+		PublishedAt time.Time      `json:"published_at"`
+		ModifiedAt  time.Time      `json:"modified_at"`
+	}
+
+	// EditControl describes versions of an edited tweet.
+	EditControl struct {
+		// InitialTweetID is the ID of the first version.
+		InitialTweetID string `json:"initial_tweet_id"`
+		// EditTweetIDs are IDs of all versions from the first to the latest.
+		EditTweetIDs   []string  `json:"edit_tweet_ids,omitempty"`
+		EditableUntil  time.Time `json:"editable_until"`
+		EditsRemaining int       `json:"edits_remaining"`
+		IsEditEligible bool      `json:"is_edit_eligible"`
+	}
+
+	// TweetCounts are engagement counts of a tweet.
+	TweetCounts struct {
+		Bookmarks int `json:"bookmarks"`
+		Likes     int `json:"likes"`
+		Quotes    int `json:"quotes"`
+		Replies   int `json:"replies"`
+		Retweets  int `json:"retweets"`
+	}
+
+	// Unavailable describes why a tweet or its content is not shown.
+	Unavailable struct {
+		Reason UnavailableReason `json:"reason"`
+		// Text is the tombstone or interstitial text shown instead of the tweet.
+		Text string `json:"text"`
 	}
 
 	// Tweet type.
 	Tweet struct {
-		ConversationID    string
-		GIFs              []GIF
-		Hashtags          []string
-		HTML              string
-		ID                string
-		InReplyToStatus   *Tweet
-		InReplyToStatusID string
-		IsQuoted          bool
-		IsPin             bool
-		IsReply           bool
-		IsRetweet         bool
-		IsSelfThread      bool
-		Likes             int
-		Name              string
-		Mentions          []Mention
-		PermanentURL      string
-		Photos            []Photo
-		Place             *Place
-		QuotedStatus      *Tweet
-		QuotedStatusID    string
-		Replies           int
-		Retweets          int
-		RetweetedStatus   *Tweet
-		RetweetedStatusID string
-		Text              string
-		Thread            []*Tweet
-		TimeParsed        time.Time
-		Timestamp         int64
-		URLs              []string
-		UserID            string
-		Username          string
-		Videos            []Video
-		Views             int
-		SensitiveContent  bool
+		Article           *Article       `json:"article,omitempty"`
+		Author            *Profile       `json:"author,omitempty"`
+		Bookmarks         int            `json:"bookmarks"`
+		Card              *Card          `json:"card,omitempty"`
+		CommunityNote     *CommunityNote `json:"community_note,omitempty"`
+		ConversationID    string         `json:"conversation_id"`
+		DisplayTextRange  [2]int         `json:"display_text_range"`
+		EditControl       *EditControl   `json:"edit_control,omitempty"`
+		Entities          Entities       `json:"entities"`
+		EntryType         EntryType      `json:"entry_type"`
+		GIFs              []GIF          `json:"gifs,omitempty"`
+		HasSuperFollower  bool           `json:"has_super_follower"`
+		Hashtags          []string       `json:"hashtags,omitempty"`
+		ID                string         `json:"id"`
+		InReplyToStatus   *Tweet         `json:"in_reply_to_status,omitempty"`
+		InReplyToStatusID string         `json:"in_reply_to_status_id"`
+		IsBlueVerified    bool           `json:"is_blue_verified"`
+		IsQuoted          bool           `json:"is_quoted"`
+		IsPin             bool           `json:"is_pin"`
+		IsReply           bool           `json:"is_reply"`
+		IsRetweet         bool           `json:"is_retweet"`
+		IsSelfThread      bool           `json:"is_self_thread"`
+		IsTranslatable    bool           `json:"is_translatable"`
+		Lang              string         `json:"lang"`
+		Likes             int            `json:"likes"`
+		LimitedActions    []string       `json:"limited_actions,omitempty"`
+		Media             []TweetMedia   `json:"media,omitempty"`
+		Name              string         `json:"name"`
+		NoteTweet         *NoteTweet     `json:"note_tweet,omitempty"`
+		Mentions          []Mention      `json:"mentions,omitempty"`
+		PermanentURL      string         `json:"permanent_url"`
+		Photos            []Photo        `json:"photos,omitempty"`
+		Place             *Place         `json:"place,omitempty"`
+		PossiblySensitive bool           `json:"possibly_sensitive"`
+		// PreviousCounts are counts of the previous version of an edited tweet.
+		PreviousCounts    *TweetCounts    `json:"previous_counts,omitempty"`
+		QuotedStatus      *Tweet          `json:"quoted_status,omitempty"`
+		QuotedStatusID    string          `json:"quoted_status_id"`
+		Quotes            int             `json:"quotes"`
+		Raw               json.RawMessage `json:"raw,omitempty"`
+		Replies           int             `json:"replies"`
+		ReplyPolicy       string          `json:"reply_policy"`
+		Retweets          int             `json:"retweets"`
+		RetweetedStatus   *Tweet          `json:"retweeted_status,omitempty"`
+		RetweetedStatusID string          `json:"retweeted_status_id"`
+		Text              string          `json:"text"`
+		Thread            []*Tweet        `json:"thread,omitempty"`
+		TimeParsed        time.Time       `json:"created_at"`
+		Timestamp         int64           `json:"timestamp"`
+		URLs              []string        `json:"urls,omitempty"`
+		Unavailable       *Unavailable    `json:"unavailable,omitempty"`
+		UserID            string          `json:"user_id"`
+		Username          string          `json:"username"`
+		Videos            []Video         `json:"videos,omitempty"`
+		Views             int             `json:"views"`
+		SensitiveContent  bool            `json:"sensitive_content"`
+		Source            string          `json:"source"`
 	}
 
+	// EntryType of a tweet in a timeline.
+	EntryType int
+
+	// UnavailableReason of a tweet which is not shown.
+	UnavailableReason int
+
 	// ProfileResult of scrapping.
 	ProfileResult struct {
 		Profile
 		Error error
 	}
 
+	// List type.
+	List struct {
+		ID              string
+		Name            string
+		Description     string
+		MemberCount     int
+		SubscriberCount int
+		Private         bool
+		Following       bool
+		BannerURL       string
+		CreatedAt       time.Time
+		Owner           *Profile
+	}
+
+	// ListResult of scrapping.
+	ListResult struct {
+		List
+		Error error
+	}
+
+	// Typeahead is autocomplete suggestions for a search prefix.
+	Typeahead struct {
+		Users    []Profile
+		Hashtags []string
+		Topics   []TypeaheadTopic
+		Queries  []string
+	}
+
+	// TypeaheadTopic is a topic suggested by typeahead.
+	TypeaheadTopic struct {
+		ID   string
+		Name string
+	}
+
+	// SavedSearch type.
+	SavedSearch struct {
+		ID        string
+		Name      string
+		Query     string
+		CreatedAt time.Time
+	}
+
 	// TweetResult of scrapping.
 	TweetResult struct {
 		Tweet
@@ -92,51 +432,27 @@ type (
 	}
 
 	legacyTweet struct {
-		ConversationIDStr string `json:"conversation_id_str"`
-		CreatedAt         string `json:"created_at"`
-		FavoriteCount     int    `json:"favorite_count"`
-		FullText          string `json:"full_text"`
-		Entities          struct {
-			Hashtags []struct {
-				Text string `json:"text"`
-			} `json:"hashtags"`
-			Media []struct {
-				MediaURLHttps string `json:"media_url_https"`
-				Type          string `json:"type"`
-				URL           string `json:"url"`
-			} `json:"media"`
-			URLs []struct {
-				ExpandedURL string `json:"expanded_url"`
-				URL         string `json:"url"`
-			} `json:"urls"`
-			UserMentions []struct {
-				IDStr      string `json:"id_str"`
-				Name       string `json:"name"`
-				ScreenName string `json:"screen_name"`
-			} `json:"user_mentions"`
-		} `json:"entities"`
-		ExtendedEntities struct {
-			Media []struct {
-				IDStr                    string `json:"id_str"`
-				MediaURLHttps            string `json:"media_url_https"`
-				ExtSensitiveMediaWarning struct {
-					AdultContent    bool `json:"adult_content"`
-					GraphicViolence bool `json:"graphic_violence"`
-					Other           bool `json:"other"`
-				} `json:"ext_sensitive_media_warning"`
-				Type      string `json:"type"`
-				URL       string `json:"url"`
-				VideoInfo struct {
-					Variants []struct {
-						Type    string `json:"content_type"`
-						Bitrate int    `json:"bitrate"`
-						URL     string `json:"url"`
-					} `json:"variants"`
-				} `json:"video_info"`
-			} `json:"media"`
+		BookmarkCount       int `json:"bookmark_count"`
+		ConversationControl *struct {
+			Policy string `json:"policy"`
+		} `json:"conversation_control"`
+		ConversationIDStr string         `json:"conversation_id_str"`
+		CreatedAt         string         `json:"created_at"`
+		FavoriteCount     int            `json:"favorite_count"`
+		FullText          string         `json:"full_text"`
+		Card              *legacyCard    `json:"card"`
+		CardURI           string         `json:"card_uri"`
+		DisplayTextRange  []int          `json:"display_text_range"`
+		EditControl       *editControlV1 `json:"ext_edit_control"`
+		Entities          legacyEntities `json:"entities"`
+		ExtendedEntities  struct {
+			Media []legacyMedia `json:"media"`
 		} `json:"extended_entities"`
 		IDStr                 string `json:"id_str"`
 		InReplyToStatusIDStr  string `json:"in_reply_to_status_id_str"`
+		Lang                  string `json:"lang"`
+		PossiblySensitive     bool   `json:"possibly_sensitive"`
+		QuoteCount            int    `json:"quote_count"`
 		Place                 Place  `json:"place"`
 		ReplyCount            int    `json:"reply_count"`
 		RetweetCount          int    `json:"retweet_count"`
@@ -148,6 +464,7 @@ type (
 		SelfThread        struct {
 			IDStr string `json:"id_str"`
 		} `json:"self_thread"`
+		Source    string    `json:"source"`
 		Time      time.Time `json:"time"`
 		UserIDStr string    `json:"user_id_str"`
 		Views     struct {
@@ -156,6 +473,74 @@ type (
 		} `json:"ext_views"`
 	}
 
+	legacyEntities struct {
+		Hashtags     []legacyTextEntity `json:"hashtags"`
+		Symbols      []legacyTextEntity `json:"symbols"`
+		Media        []legacyMedia      `json:"media"`
+		URLs         []legacyURLEntity  `json:"urls"`
+		UserMentions []struct {
+			IDStr      string `json:"id_str"`
+			Name       string `json:"name"`
+			ScreenName string `json:"screen_name"`
+			Indices    []int  `json:"indices"`
+		} `json:"user_mentions"`
+	}
+
+	legacyTextEntity struct {
+		Text    string `json:"text"`
+		Indices []int  `json:"indices"`
+	}
+
+	legacyURLEntity struct {
+		DisplayURL  string `json:"display_url"`
+		ExpandedURL string `json:"expanded_url"`
+		URL         string `json:"url"`
+		Indices     []int  `json:"indices"`
+		Unwound     struct {
+			URL         string `json:"url"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"unwound"`
+	}
+
+	legacySensitiveMediaWarning struct {
+		AdultContent    bool `json:"adult_content"`
+		GraphicViolence bool `json:"graphic_violence"`
+		Other           bool `json:"other"`
+	}
+
+	legacyMedia struct {
+		IDStr         string `json:"id_str"`
+		MediaKey      string `json:"media_key"`
+		MediaURLHttps string `json:"media_url_https"`
+		Type          string `json:"type"`
+		URL           string `json:"url"`
+		DisplayURL    string `json:"display_url"`
+		ExpandedURL   string `json:"expanded_url"`
+		Indices       []int  `json:"indices"`
+		ExtAltText    string `json:"ext_alt_text"`
+		OriginalInfo  struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"original_info"`
+		ExtMediaAvailability struct {
+			Status string `json:"status"`
+			Reason string `json:"reason"`
+		} `json:"ext_media_availability"`
+		// v1 timelines use the ext_ prefix, GraphQL doesn't
+		ExtSensitiveMediaWarning *legacySensitiveMediaWarning `json:"ext_sensitive_media_warning"`
+		SensitiveMediaWarning    *legacySensitiveMediaWarning `json:"sensitive_media_warning"`
+		VideoInfo                struct {
+			AspectRatio    []int `json:"aspect_ratio"`
+			DurationMillis int   `json:"duration_millis"`
+			Variants       []struct {
+				Type    string `json:"content_type"`
+				Bitrate int    `json:"bitrate"`
+				URL     string `json:"url"`
+			} `json:"variants"`
+		} `json:"video_info"`
+	}
+
 	legacyUser struct {
 		CreatedAt   string `json:"created_at"`
 		Description string `json:"description"`
@@ -170,6 +555,8 @@ type (
 		FollowersCount       int      `json:"followers_count"`
 		FriendsCount         int      `json:"friends_count"`
 		IDStr                string   `json:"id_str"`
+		IsBlueVerified       bool     `json:"ext_is_blue_verified"`
+		VerifiedType         string   `json:"verified_type"`
 		ListedCount          int      `json:"listed_count"`
 		Name                 string   `json:"name"`
 		Location             string   `json:"location"`
@@ -227,6 +614,7 @@ type (
 		TranslatorType          string        `json:"translator_type"`
 		URL                     string        `json:"url"`
 		Verified                bool          `json:"verified"`
+		VerifiedType            string        `json:"verified_type"`
 		WantRetweets            bool          `json:"want_retweets"`
 		WithheldInCountries     []interface{} `json:"withheld_in_countries"`
 	}
@@ -244,6 +632,30 @@ type (
 		} `json:"bounding_box"`
 	}
 
+	fetchListFunc    func(query string, maxListsNbr int, cursor string) ([]*List, string, error)
 	fetchProfileFunc func(query string, maxProfilesNbr int, cursor string) ([]*Profile, string, error)
 	fetchTweetFunc   func(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error)
 )
+
+const (
+	// EntryOrganic - regular timeline entry
+	EntryOrganic EntryType = iota
+	// EntryPromoted - ad
+	EntryPromoted
+	// EntryModuleContext - parent tweet shown above a reply in conversation module
+	EntryModuleContext
+)
+
+func parseEntryType(entryID string, promoted bool) EntryType {
+	if promoted || strings.HasPrefix(entryID, "promoted") {
+		return EntryPromoted
+	}
+	return EntryOrganic
+}
+
+// CreatedAtFromID returns the creation time encoded in the tweet ID with millisecond precision,
+// TimeParsed has only seconds. It is zero for IDs assigned before November 2010.
+func (tweet *Tweet) CreatedAtFromID() time.Time {
+	tm, _ := snowflake.Time(tweet.ID)
+	return tm
+}
@@ -0,0 +1,131 @@
+package twitterscraper
+
+import "strings"
+
+const (
+	// UnavailableUnknown - the reason is not recognized
+	UnavailableUnknown UnavailableReason = iota
+	// UnavailableDeleted - deleted by the author or removed
+	UnavailableDeleted
+	// UnavailableSuspended - the author account is suspended
+	UnavailableSuspended
+	// UnavailableProtected - the author protects their tweets
+	UnavailableProtected
+	// UnavailableWithheld - withheld in the country of the viewer
+	UnavailableWithheld
+	// UnavailableAgeRestricted - adult content hidden from logged out or underage viewers
+	UnavailableAgeRestricted
+	// UnavailableLimitedVisibility - the tweet is shown behind an interstitial
+	UnavailableLimitedVisibility
+)
+
+type tombstoneText struct {
+	Text struct {
+		Text string `json:"text"`
+	} `json:"text"`
+}
+
+// unavailableReasons maps reasons of TweetUnavailable results.
+var unavailableReasons = map[string]UnavailableReason{
+	"Deleted":                  UnavailableDeleted,
+	"Suspended":                UnavailableSuspended,
+	"Protected":                UnavailableProtected,
+	"Withheld":                 UnavailableWithheld,
+	"NsfwLoggedOut":            UnavailableAgeRestricted,
+	"NsfwViewerIsUnderage":     UnavailableAgeRestricted,
+	"NsfwViewerHasNoStatedAge": UnavailableAgeRestricted,
+}
+
+// parseTombstoneReason guesses the reason from the tombstone text, as tombstones have no reason code.
+func parseTombstoneReason(text string) UnavailableReason {
+	text = strings.ToLower(text)
+	switch {
+	case strings.Contains(text, "deleted"):
+		return UnavailableDeleted
+	case strings.Contains(text, "suspended"):
+		return UnavailableSuspended
+	case strings.Contains(text, "limits who can view") || strings.Contains(text, "protected"):
+		return UnavailableProtected
+	case strings.Contains(text, "withheld"):
+		return UnavailableWithheld
+	case strings.Contains(text, "age-restricted") || strings.Contains(text, "adult content"):
+		return UnavailableAgeRestricted
+	}
+	return UnavailableUnknown
+}
+
+// isTweetOrTombstone reports whether the result is a tweet or takes the place of an unavailable one.
+func (result *result) isTweetOrTombstone() bool {
+	switch result.Typename {
+	case "Tweet", "TweetWithVisibilityResults", "TweetTombstone", "TweetUnavailable":
+		return true
+	}
+	return false
+}
+
+// unavailable returns why the result has no tweet, or nil if it has one.
+func (result *result) unavailable() *Unavailable {
+	switch result.Typename {
+	case "TweetTombstone":
+		unavailable := &Unavailable{Reason: UnavailableUnknown}
+		if result.Tombstone != nil {
+			unavailable.Text = result.Tombstone.Text.Text
+			unavailable.Reason = parseTombstoneReason(unavailable.Text)
+		}
+		return unavailable
+	case "TweetUnavailable":
+		unavailable := &Unavailable{Reason: unavailableReasons[result.Reason]}
+		if result.Tombstone != nil {
+			unavailable.Text = result.Tombstone.Text.Text
+			if unavailable.Reason == UnavailableUnknown {
+				unavailable.Reason = parseTombstoneReason(unavailable.Text)
+			}
+		}
+		return unavailable
+	}
+	return nil
+}
+
+// interstitial returns the visibility limit shown over a tweet with visibility results.
+func (result *result) interstitial() *Unavailable {
+	if result.TweetInterstitial == nil || result.TweetInterstitial.Text.Text == "" {
+		return nil
+	}
+	unavailable := &Unavailable{
+		Reason: UnavailableLimitedVisibility,
+		Text:   result.TweetInterstitial.Text.Text,
+	}
+	if parseTombstoneReason(unavailable.Text) == UnavailableAgeRestricted {
+		unavailable.Reason = UnavailableAgeRestricted
+	}
+	return unavailable
+}
+
+// parseWithUnavailable parses the result like parse, but returns a tweet with only ID
+// and Unavailable set when the result is a tombstone or unavailable tweet.
+func (result *result) parseWithUnavailable(id string) *Tweet {
+	unavailable := result.unavailable()
+	if unavailable == nil {
+		return result.parse()
+	}
+	if id == "" {
+		return nil
+	}
+	return &Tweet{ID: id, Unavailable: unavailable, Raw: result.Raw.payload()}
+}
+
+// entryTweetID returns the tweet ID at the end of timeline entry IDs like tweet-ID
+// or conversationthread-ID-tweet-ID.
+func entryTweetID(entryID string) string {
+	i := strings.LastIndex(entryID, "tweet-")
+	if i < 0 {
+		return ""
+	}
+	id := entryID[i+len("tweet-"):]
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return ""
+		}
+	}
+	return id
+}
@@ -0,0 +1,128 @@
+package twitterscraper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const unavailableConversationJSON = `{"data": {"threaded_conversation_with_injections_v2": {"instructions": [{
+	"type": "TimelineAddEntries",
+	"entries": [
+		{"entryId": "tweet-100", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "Tweet",
+			"core": {"user_results": {"result": {"rest_id": "1", "legacy": {"screen_name": "x", "name": "X"}}}},
+			"legacy": {"id_str": "100", "full_text": "focal", "user_id_str": "1", "conversation_id_str": "100"}
+		}}}}},
+		{"entryId": "tweet-101", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "TweetTombstone",
+			"tombstone": {"__typename": "TextTombstone", "text": {"text": "This Post was deleted by the Post author. Learn more"}}
+		}}}}},
+		{"entryId": "conversationthread-102", "content": {"items": [
+			{"entryId": "conversationthread-102-tweet-102", "item": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+				"__typename": "TweetUnavailable",
+				"reason": "Suspended"
+			}}}}},
+			{"entryId": "conversationthread-102-tweet-103", "item": {"itemContent": {"itemType": "TimelineTweet", "tweetDisplayType": "SelfThread", "tweet_results": {"result": {
+				"__typename": "TweetWithVisibilityResults",
+				"tweet": {
+					"core": {"user_results": {"result": {"rest_id": "1", "legacy": {"screen_name": "x", "name": "X"}}}},
+					"legacy": {"id_str": "103", "full_text": "sensitive", "user_id_str": "1", "conversation_id_str": "100"}
+				},
+				"tweetInterstitial": {"__typename": "ContextualTweetInterstitial", "text": {"text": "Age-restricted adult content. This content might not be appropriate for people under 18 years old."}}
+			}}}}}
+		]}},
+		{"entryId": "tweet-104", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "TweetUnavailable",
+			"reason": "NsfwLoggedOut",
+			"tombstone": {"__typename": "TextTombstone", "text": {"text": "Age-restricted adult content."}}
+		}}}}},
+		{"entryId": "tweet-105", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "TweetWithVisibilityResults",
+			"tweet": {
+				"core": {"user_results": {"result": {"rest_id": "2", "legacy": {"screen_name": "y", "name": "Y"}}}},
+				"legacy": {"id_str": "105", "full_text": "limited", "user_id_str": "2", "conversation_id_str": "100"}
+			},
+			"tweetInterstitial": {"__typename": "ContextualTweetInterstitial", "text": {"text": "This Post violated the X Rules."}}
+		}}}}},
+		{"entryId": "tweet-106", "content": {"itemContent": {"itemType": "TimelineTweet", "tweet_results": {"result": {
+			"__typename": "TweetUnavailable"
+		}}}}},
+		{"entryId": "cursor-bottom-107", "content": {"itemContent": {"itemType": "TimelineTimelineCursor", "cursorType": "Bottom", "value": "cursor"}}}
+	]
+}]}}}`
+
+func TestThreadedConversationUnavailable(t *testing.T) {
+	var conversation threadedConversation
+	if err := json.Unmarshal([]byte(unavailableConversationJSON), &conversation); err != nil {
+		t.Fatal(err)
+	}
+	tweets, cursors := conversation.parse("100")
+
+	type parsed struct {
+		ID           string
+		Text         string
+		IsSelfThread bool
+		Unavailable  *Unavailable
+	}
+	var actual []parsed
+	for _, tweet := range tweets {
+		actual = append(actual, parsed{tweet.ID, tweet.Text, tweet.IsSelfThread, tweet.Unavailable})
+	}
+	expected := []parsed{
+		{ID: "100", Text: "focal"},
+		{ID: "101", Unavailable: &Unavailable{Reason: UnavailableDeleted, Text: "This Post was deleted by the Post author. Learn more"}},
+		{ID: "102", Unavailable: &Unavailable{Reason: UnavailableSuspended}},
+		{ID: "103", Text: "sensitive", IsSelfThread: true, Unavailable: &Unavailable{
+			Reason: UnavailableAgeRestricted,
+			Text:   "Age-restricted adult content. This content might not be appropriate for people under 18 years old.",
+		}},
+		{ID: "104", Unavailable: &Unavailable{Reason: UnavailableAgeRestricted, Text: "Age-restricted adult content."}},
+		{ID: "105", Text: "limited", Unavailable: &Unavailable{Reason: UnavailableLimitedVisibility, Text: "This Post violated the X Rules."}},
+		{ID: "106", Unavailable: &Unavailable{Reason: UnavailableUnknown}},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Error("Parsed conversation does not match", diff)
+	}
+	if len(cursors) != 1 || cursors[0].Cursor != "cursor" {
+		t.Errorf("Expected bottom cursor is kept, got: %v", cursors)
+	}
+}
+
+func TestParseTombstoneReason(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected UnavailableReason
+	}{
+		{"This Post was deleted by the Post author. Learn more", UnavailableDeleted},
+		{"This Post is from a suspended account. Learn more", UnavailableSuspended},
+		{"You're unable to view this Post because this account owner limits who can view their Posts. Learn more", UnavailableProtected},
+		{"This Tweet is from an account that is protected.", UnavailableProtected},
+		{"This Post has been withheld in Germany in response to a legal demand.", UnavailableWithheld},
+		{"Age-restricted adult content. This content might not be appropriate for people under 18 years old.", UnavailableAgeRestricted},
+		{"This Post contains adult content.", UnavailableAgeRestricted},
+		{"This Post is unavailable. Learn more", UnavailableUnknown},
+		{"", UnavailableUnknown},
+	}
+	for _, test := range tests {
+		if reason := parseTombstoneReason(test.text); reason != test.expected {
+			t.Errorf("parseTombstoneReason(%q) = %v, expected %v", test.text, reason, test.expected)
+		}
+	}
+}
+
+func TestEntryTweetID(t *testing.T) {
+	tests := map[string]string{
+		"tweet-1697304622749086011":                                  "1697304622749086011",
+		"conversationthread-1697304622749086011-tweet-1697304622749": "1697304622749",
+		"conversationthread-1697304622749086011":                     "",
+		"tweet-1-promoted":                                           "",
+		"cursor-bottom-1":                                            "",
+	}
+	for entryID, expected := range tests {
+		if id := entryTweetID(entryID); id != expected {
+			t.Errorf("entryTweetID(%q) = %q, expected %q", entryID, id, expected)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 var (
 	reHashtag    = regexp.MustCompile(`\B(\#\S+\b)`)
+	reSource     = regexp.MustCompile(`>([^<]*)</a>`)
 	reTwitterURL = regexp.MustCompile(`https:(\/\/t\.co\/([A-Za-z0-9]|[A-Za-z]){10})`)
 	reUsername   = regexp.MustCompile(`\B(\@\S{1,15}\b)`)
 	twURL        = urlParse("https://twitter.com")
@@ -62,94 +63,12 @@ func (s *Scraper) newRequest(method string, url string) (*http.Request, error) {
 	return req, nil
 }
 
-func getUserTimeline(ctx context.Context, query string, maxProfilesNbr int, fetchFunc fetchProfileFunc) <-chan *ProfileResult {
-	channel := make(chan *ProfileResult)
-	go func(query string) {
-		defer close(channel)
-		var nextCursor string
-		profilesNbr := 0
-		for profilesNbr < maxProfilesNbr {
-			select {
-			case <-ctx.Done():
-				channel <- &ProfileResult{Error: ctx.Err()}
-				return
-			default:
-			}
-
-			profiles, next, err := fetchFunc(query, maxProfilesNbr, nextCursor)
-			if err != nil {
-				channel <- &ProfileResult{Error: err}
-				return
-			}
-
-			if len(profiles) == 0 {
-				break
-			}
-
-			for _, profile := range profiles {
-				select {
-				case <-ctx.Done():
-					channel <- &ProfileResult{Error: ctx.Err()}
-					return
-				default:
-				}
-
-				if profilesNbr < maxProfilesNbr {
-					nextCursor = next
-					channel <- &ProfileResult{Profile: *profile}
-				} else {
-					break
-				}
-				profilesNbr++
-			}
-		}
-	}(query)
-	return channel
+func (s *Scraper) getUserTimeline(ctx context.Context, operation string, query string, maxProfilesNbr int, fetchFunc fetchProfileFunc) <-chan *ProfileResult {
+	return s.newProfileIterator(ctx, operation, query, maxProfilesNbr, fetchFunc).Chan()
 }
 
-func getTweetTimeline(ctx context.Context, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *TweetResult {
-	channel := make(chan *TweetResult)
-	go func(query string) {
-		defer close(channel)
-		var nextCursor string
-		tweetsNbr := 0
-		for tweetsNbr < maxTweetsNbr {
-			select {
-			case <-ctx.Done():
-				channel <- &TweetResult{Error: ctx.Err()}
-				return
-			default:
-			}
-
-			tweets, next, err := fetchFunc(query, maxTweetsNbr, nextCursor)
-			if err != nil {
-				channel <- &TweetResult{Error: err}
-				return
-			}
-
-			if len(tweets) == 0 {
-				break
-			}
-
-			for _, tweet := range tweets {
-				select {
-				case <-ctx.Done():
-					channel <- &TweetResult{Error: ctx.Err()}
-					return
-				default:
-				}
-
-				if tweetsNbr < maxTweetsNbr {
-					nextCursor = next
-					channel <- &TweetResult{Tweet: *tweet}
-				} else {
-					break
-				}
-				tweetsNbr++
-			}
-		}
-	}(query)
-	return channel
+func (s *Scraper) getTweetTimeline(ctx context.Context, operation string, query string, maxTweetsNbr int, fetchFunc fetchTweetFunc) <-chan *TweetResult {
+	return s.newTweetIterator(ctx, operation, query, maxTweetsNbr, fetchFunc).Chan()
 }
 
 func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
@@ -160,16 +79,25 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 	username := user.ScreenName
 	name := user.Name
 	tw := &Tweet{
-		ConversationID: tweet.ConversationIDStr,
-		ID:             tweetID,
-		Likes:          tweet.FavoriteCount,
-		Name:           name,
-		PermanentURL:   fmt.Sprintf("https://twitter.com/%s/status/%s", username, tweetID),
-		Replies:        tweet.ReplyCount,
-		Retweets:       tweet.RetweetCount,
-		Text:           tweet.FullText,
-		UserID:         tweet.UserIDStr,
-		Username:       username,
+		Bookmarks:         tweet.BookmarkCount,
+		ConversationID:    tweet.ConversationIDStr,
+		ID:                tweetID,
+		IsBlueVerified:    user.IsBlueVerified,
+		Lang:              tweet.Lang,
+		Likes:             tweet.FavoriteCount,
+		Name:              name,
+		PermanentURL:      fmt.Sprintf("https://twitter.com/%s/status/%s", username, tweetID),
+		PossiblySensitive: tweet.PossiblySensitive,
+		Quotes:            tweet.QuoteCount,
+		Replies:           tweet.ReplyCount,
+		Retweets:          tweet.RetweetCount,
+		Source:            parseSource(tweet.Source),
+		Text:              tweet.FullText,
+		UserID:            tweet.UserIDStr,
+		Username:          username,
+	}
+	if tweet.ConversationControl != nil {
+		tw.ReplyPolicy = tweet.ConversationControl.Policy
 	}
 
 	tm, err := time.Parse(time.RubyDate, tweet.CreatedAt)
@@ -194,14 +122,10 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 		tw.IsRetweet = true
 		tw.RetweetedStatusID = tweet.RetweetedStatusIDStr
 		if tweet.RetweetedStatusResult.Result != nil {
-			var legacy *legacyTweet = &tweet.RetweetedStatusResult.Result.Legacy
-			var user *legacyUser = &tweet.RetweetedStatusResult.Result.Core.UserResults.Result.Legacy
-			if tweet.RetweetedStatusResult.Result.Typename == "TweetWithVisibilityResults" {
-				legacy = &tweet.RetweetedStatusResult.Result.Tweet.Legacy
-				user = &tweet.RetweetedStatusResult.Result.Tweet.Core.UserResults.Result.Legacy
+			if retweeted := tweet.RetweetedStatusResult.Result.parseWithUnavailable(tweet.RetweetedStatusIDStr); retweeted != nil {
+				tw.RetweetedStatus = retweeted
+				tw.RetweetedStatusID = retweeted.ID
 			}
-			tw.RetweetedStatus = parseLegacyTweet(user, legacy)
-			tw.RetweetedStatusID = tw.RetweetedStatus.ID
 		}
 	}
 
@@ -281,8 +205,8 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 		}
 
 		if !tw.SensitiveContent {
-			sensitive := media.ExtSensitiveMediaWarning
-			tw.SensitiveContent = sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other
+			sensitive := media.sensitiveWarning()
+			tw.SensitiveContent = sensitive != nil && (sensitive.AdultContent || sensitive.GraphicViolence || sensitive.Other)
 		}
 	}
 
@@ -290,57 +214,163 @@ func parseLegacyTweet(user *legacyUser, tweet *legacyTweet) *Tweet {
 		tw.URLs = append(tw.URLs, url.ExpandedURL)
 	}
 
-	tw.HTML = tweet.FullText
-	tw.HTML = reHashtag.ReplaceAllStringFunc(tw.HTML, func(hashtag string) string {
+	tw.DisplayTextRange = parseIndices(tweet.DisplayTextRange)
+	tw.Entities = parseEntities(tweet)
+	tw.Media = parseMedia(tweet)
+	if tweet.Card != nil {
+		if tw.Card = tweet.Card.parse(tw); tw.Card != nil {
+			tw.Card.ID = tweet.CardURI
+		}
+	}
+	if tweet.EditControl != nil {
+		tw.EditControl = tweet.EditControl.parse(tw.ID)
+	}
+
+	return tw
+}
+
+// HTML renders the tweet text as HTML with hashtags, mentions, links and media previews.
+// It is built on every call, package render produces escaped HTML from entity indices.
+func (tweet *Tweet) HTML() string {
+	html := reHashtag.ReplaceAllStringFunc(tweet.Text, func(hashtag string) string {
 		return fmt.Sprintf(`<a href="https://twitter.com/hashtag/%s">%s</a>`,
 			strings.TrimPrefix(hashtag, "#"),
 			hashtag,
 		)
 	})
-	tw.HTML = reUsername.ReplaceAllStringFunc(tw.HTML, func(username string) string {
+	html = reUsername.ReplaceAllStringFunc(html, func(username string) string {
 		return fmt.Sprintf(`<a href="https://twitter.com/%s">%s</a>`,
 			strings.TrimPrefix(username, "@"),
 			username,
 		)
 	})
 	var foundedMedia []string
-	tw.HTML = reTwitterURL.ReplaceAllStringFunc(tw.HTML, func(tco string) string {
+	html = reTwitterURL.ReplaceAllStringFunc(html, func(tco string) string {
 		for _, entity := range tweet.Entities.URLs {
 			if tco == entity.URL {
 				return fmt.Sprintf(`<a href="%s">%s</a>`, entity.ExpandedURL, tco)
 			}
 		}
-		for _, entity := range tweet.ExtendedEntities.Media {
-			if tco == entity.URL {
-				foundedMedia = append(foundedMedia, entity.MediaURLHttps)
-				return fmt.Sprintf(`<br><a href="%s"><img src="%s"/></a>`, tco, entity.MediaURLHttps)
+		for _, media := range tweet.Media {
+			if tco == media.URL {
+				foundedMedia = append(foundedMedia, media.MediaURL)
+				return fmt.Sprintf(`<br><a href="%s"><img src="%s"/></a>`, tco, media.MediaURL)
 			}
 		}
 		return tco
 	})
-	for _, photo := range tw.Photos {
+	for _, photo := range tweet.Photos {
 		url := photo.URL
 		if stringInSlice(url, foundedMedia) {
 			continue
 		}
-		tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
+		html += fmt.Sprintf(`<br><img src="%s"/>`, url)
 	}
-	for _, video := range tw.Videos {
+	for _, video := range tweet.Videos {
 		url := video.Preview
 		if stringInSlice(url, foundedMedia) {
 			continue
 		}
-		tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
+		html += fmt.Sprintf(`<br><img src="%s"/>`, url)
 	}
-	for _, gif := range tw.GIFs {
+	for _, gif := range tweet.GIFs {
 		url := gif.Preview
 		if stringInSlice(url, foundedMedia) {
 			continue
 		}
-		tw.HTML += fmt.Sprintf(`<br><img src="%s"/>`, url)
+		html += fmt.Sprintf(`<br><img src="%s"/>`, url)
 	}
-	tw.HTML = strings.Replace(tw.HTML, "\n", "<br>", -1)
-	return tw
+	return strings.Replace(html, "\n", "<br>", -1)
+}
+
+func parseIndices(indices []int) [2]int {
+	var parsed [2]int
+	copy(parsed[:], indices)
+	return parsed
+}
+
+func parseEntities(tweet *legacyTweet) Entities {
+	var entities Entities
+	for _, hashtag := range tweet.Entities.Hashtags {
+		entities.Hashtags = append(entities.Hashtags, TextEntity{Text: hashtag.Text, Indices: parseIndices(hashtag.Indices)})
+	}
+	for _, symbol := range tweet.Entities.Symbols {
+		entities.Cashtags = append(entities.Cashtags, TextEntity{Text: symbol.Text, Indices: parseIndices(symbol.Indices)})
+	}
+	for _, mention := range tweet.Entities.UserMentions {
+		entities.Mentions = append(entities.Mentions, MentionEntity{
+			ID:       mention.IDStr,
+			Username: mention.ScreenName,
+			Name:     mention.Name,
+			Indices:  parseIndices(mention.Indices),
+		})
+	}
+	for _, url := range tweet.Entities.URLs {
+		entities.URLs = append(entities.URLs, URLEntity{
+			URL:         url.URL,
+			DisplayURL:  url.DisplayURL,
+			ExpandedURL: url.ExpandedURL,
+			UnwoundURL:  url.Unwound.URL,
+			Indices:     parseIndices(url.Indices),
+		})
+	}
+	return entities
+}
+
+// parseMedia returns every media of a tweet, extended entities hold all of them
+// while entities only have the first one.
+func parseMedia(tweet *legacyTweet) []TweetMedia {
+	medias := tweet.ExtendedEntities.Media
+	if len(medias) == 0 {
+		medias = tweet.Entities.Media
+	}
+	var parsed []TweetMedia
+	for _, media := range medias {
+		item := TweetMedia{
+			ID:                media.IDStr,
+			MediaKey:          media.MediaKey,
+			Type:              media.Type,
+			MediaURL:          media.MediaURLHttps,
+			URL:               media.URL,
+			DisplayURL:        media.DisplayURL,
+			ExpandedURL:       media.ExpandedURL,
+			Indices:           parseIndices(media.Indices),
+			Width:             media.OriginalInfo.Width,
+			Height:            media.OriginalInfo.Height,
+			AspectRatio:       parseIndices(media.VideoInfo.AspectRatio),
+			Duration:          time.Duration(media.VideoInfo.DurationMillis) * time.Millisecond,
+			AltText:           media.ExtAltText,
+			Availability:      media.ExtMediaAvailability.Status,
+			UnavailableReason: media.ExtMediaAvailability.Reason,
+		}
+		for _, variant := range media.VideoInfo.Variants {
+			item.Variants = append(item.Variants, VideoVariant{
+				URL:         variant.URL,
+				ContentType: variant.Type,
+				Bitrate:     variant.Bitrate,
+			})
+		}
+		if sensitive := media.sensitiveWarning(); sensitive != nil {
+			if sensitive.AdultContent {
+				item.SensitiveWarnings = append(item.SensitiveWarnings, "adult_content")
+			}
+			if sensitive.GraphicViolence {
+				item.SensitiveWarnings = append(item.SensitiveWarnings, "graphic_violence")
+			}
+			if sensitive.Other {
+				item.SensitiveWarnings = append(item.SensitiveWarnings, "other")
+			}
+		}
+		parsed = append(parsed, item)
+	}
+	return parsed
+}
+
+func (media *legacyMedia) sensitiveWarning() *legacySensitiveMediaWarning {
+	if media.ExtSensitiveMediaWarning != nil {
+		return media.ExtSensitiveMediaWarning
+	}
+	return media.SensitiveMediaWarning
 }
 
 func parseProfile(user legacyUser) Profile {
@@ -351,6 +381,7 @@ func parseProfile(user legacyUser) Profile {
 		FollowersCount: user.FollowersCount,
 		FollowingCount: user.FavouritesCount,
 		FriendsCount:   user.FriendsCount,
+		IsBlueVerified: user.IsBlueVerified,
 		IsVerified:     user.Verified,
 		IsPrivate:      user.Protected,
 		LikesCount:     user.FavouritesCount,
@@ -362,6 +393,7 @@ func parseProfile(user legacyUser) Profile {
 		URL:            "https://twitter.com/" + user.ScreenName,
 		UserID:         user.IDStr,
 		Username:       user.ScreenName,
+		VerifiedType:   user.VerifiedType,
 		FollowedBy:     user.FollowedBy,
 		Following:      user.Following,
 	}
@@ -388,6 +420,7 @@ func parseProfileV2(user userResult) Profile {
 		FollowersCount: u.FollowersCount,
 		FollowingCount: u.FavouritesCount,
 		FriendsCount:   u.FriendsCount,
+		IsBlueVerified: user.IsBlueVerified,
 		IsVerified:     u.Verified,
 		LikesCount:     u.FavouritesCount,
 		ListedCount:    u.ListedCount,
@@ -398,9 +431,11 @@ func parseProfileV2(user userResult) Profile {
 		URL:            "https://twitter.com/" + u.ScreenName,
 		UserID:         user.ID,
 		Username:       u.ScreenName,
+		VerifiedType:   u.VerifiedType,
 		Sensitive:      u.PossiblySensitive,
 		Following:      u.Following,
 		FollowedBy:     u.FollowedBy,
+		Raw:            user.Raw.payload(),
 	}
 
 	tm, err := time.Parse(time.RubyDate, u.CreatedAt)
@@ -440,3 +475,11 @@ func urlParse(u string) *url.URL {
 	}
 	return parsed
 }
+
+// parseSource returns the client name from the source link of a tweet.
+func parseSource(source string) string {
+	if match := reSource.FindStringSubmatch(source); match != nil {
+		return match[1]
+	}
+	return source
+}
@@ -0,0 +1,321 @@
+package twitterscraper
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/imperatrona/twitter-scraper/snowflake"
+)
+
+// WatchSource is a timeline polled by a Watcher.
+type WatchSource struct {
+	Operation string
+	Query     string
+}
+
+// WatchUser watches tweets of a user.
+func WatchUser(username string) WatchSource {
+	return WatchSource{Operation: OperationTweets, Query: username}
+}
+
+// WatchSearch watches latest tweets for a search query.
+func WatchSearch(query string) WatchSource {
+	return WatchSource{Operation: OperationSearchTweets, Query: query}
+}
+
+// WatchList watches latest tweets of a list.
+func WatchList(listID string) WatchSource {
+	return WatchSource{Operation: OperationListTweets, Query: listID}
+}
+
+// WatchHome watches the following timeline of the logged in user.
+func WatchHome() WatchSource {
+	return WatchSource{Operation: OperationHomeTweets}
+}
+
+// WatchMentions watches latest tweets mentioning a user.
+func WatchMentions(username string) WatchSource {
+	return WatchSource{Operation: OperationMentions, Query: username}
+}
+
+// WatchOptions configures a Watcher.
+type WatchOptions struct {
+	// Interval between polls, 5 minutes by default.
+	Interval time.Duration
+	// MaxBackoff caps the wait after failed polls, which doubles on every failure.
+	// 1 hour by default.
+	MaxBackoff time.Duration
+	// PageSize is the number of tweets requested per page, 20 by default.
+	PageSize int
+	// MaxPages limits how many pages a poll fetches to reach the last seen tweet,
+	// 10 by default. Tweets beyond it are skipped.
+	MaxPages int
+	// Backfill is the number of latest tweets emitted by the first poll.
+	// By default the first poll only remembers where the timeline is.
+	Backfill int
+	// LastID is the last seen tweet ID to start from, it overrides the saved one.
+	LastID string
+	// Checkpointer persists the last seen tweet ID, it is saved under the
+	// source operation prefixed with "Watch".
+	Checkpointer Checkpointer
+	// OnError is called with errors of failed polls, which are retried after backoff.
+	OnError func(err error)
+}
+
+// Watcher polls a timeline and emits only tweets newer than the last seen one.
+type Watcher struct {
+	scraper   *Scraper
+	source    WatchSource
+	options   WatchOptions
+	fetchFunc fetchTweetFunc
+	lastID    string
+	loaded    bool
+}
+
+// NewWatcher creates a Watcher for source.
+func (s *Scraper) NewWatcher(source WatchSource, options WatchOptions) *Watcher {
+	if options.Interval <= 0 {
+		options.Interval = 5 * time.Minute
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = time.Hour
+	}
+	if options.PageSize <= 0 {
+		options.PageSize = 20
+	}
+	if options.MaxPages <= 0 {
+		options.MaxPages = 10
+	}
+
+	w := &Watcher{scraper: s, source: source, options: options}
+	switch source.Operation {
+	case OperationTweets:
+		w.fetchFunc = s.FetchTweets
+	case OperationSearchTweets:
+		w.fetchFunc = s.fetchLatestSearchTweets
+	case OperationListTweets:
+		w.fetchFunc = s.FetchListTweets
+	case OperationHomeTweets:
+		w.fetchFunc = s.fetchHomeTweets
+	case OperationMentions:
+		w.fetchFunc = func(username string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+			return s.fetchLatestSearchTweets("@"+username, maxTweetsNbr, cursor)
+		}
+	}
+	return w
+}
+
+// LastID returns the last seen tweet ID.
+func (w *Watcher) LastID() string {
+	return w.lastID
+}
+
+// Poll fetches the timeline once and returns new tweets from oldest to newest.
+func (w *Watcher) Poll(ctx context.Context) ([]*Tweet, error) {
+	tweets, lastID, err := w.poll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.commit(lastID); err != nil {
+		return nil, err
+	}
+	return tweets, nil
+}
+
+// Run polls the timeline until ctx is done and calls fn for every new tweet.
+// Failed polls are retried with backoff, an error returned by fn stops Run.
+func (w *Watcher) Run(ctx context.Context, fn func(tweet *Tweet) error) error {
+	failures := 0
+	for {
+		wait := w.options.Interval
+		tweets, lastID, err := w.poll(ctx)
+		if err == nil {
+			for _, tweet := range tweets {
+				if err := fn(tweet); err != nil {
+					w.commit(w.lastIDBefore(tweets, tweet))
+					return err
+				}
+			}
+			err = w.commit(lastID)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failures++
+			wait = w.backoff(failures)
+			if w.options.OnError != nil {
+				w.options.OnError(err)
+			}
+		} else {
+			failures = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Watch returns channel with new tweets of source, polled until ctx is done.
+// Errors of failed polls are sent to the channel and polling goes on.
+func (s *Scraper) Watch(ctx context.Context, source WatchSource, options WatchOptions) <-chan *TweetResult {
+	channel := make(chan *TweetResult)
+	send := func(result *TweetResult) error {
+		select {
+		case channel <- result:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	onError := options.OnError
+	options.OnError = func(err error) {
+		if onError != nil {
+			onError(err)
+		}
+		send(&TweetResult{Error: err})
+	}
+
+	go func() {
+		defer close(channel)
+		s.NewWatcher(source, options).Run(ctx, func(tweet *Tweet) error {
+			return send(&TweetResult{Tweet: *tweet})
+		})
+	}()
+	return channel
+}
+
+// poll returns new tweets sorted from oldest to newest and the ID to remember after them.
+func (w *Watcher) poll(ctx context.Context) ([]*Tweet, string, error) {
+	if w.fetchFunc == nil {
+		return nil, "", errors.New("unsupported watch source " + w.source.Operation)
+	}
+	if err := w.load(); err != nil {
+		return nil, "", err
+	}
+
+	var tweets []*Tweet
+	seen := make(map[string]bool)
+	cursor := ""
+	for page := 0; page < w.options.MaxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+		items, next, err := w.fetchFunc(w.source.Query, w.options.PageSize, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		reached := false
+		for _, tweet := range items {
+			if tweet.EntryType == EntryPromoted {
+				continue
+			}
+			if w.lastID != "" && snowflake.Compare(tweet.ID, w.lastID) <= 0 {
+				// pinned and conversation context tweets are out of order,
+				// any other old tweet means the last seen one was reached even if it was deleted
+				if !tweet.IsPin && tweet.EntryType == EntryOrganic {
+					reached = true
+				}
+				continue
+			}
+			if !seen[tweet.ID] {
+				seen[tweet.ID] = true
+				tweets = append(tweets, tweet)
+			}
+		}
+
+		// the first poll only needs the latest page
+		if reached || w.lastID == "" || len(items) == 0 || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+
+	sort.SliceStable(tweets, func(i, j int) bool {
+		return snowflake.Compare(tweets[i].ID, tweets[j].ID) < 0
+	})
+	if len(tweets) == 0 {
+		return nil, w.lastID, nil
+	}
+	lastID := tweets[len(tweets)-1].ID
+	if w.lastID == "" {
+		if w.options.Backfill < len(tweets) {
+			tweets = tweets[len(tweets)-w.options.Backfill:]
+		}
+	}
+	return tweets, lastID, nil
+}
+
+// lastIDBefore returns the ID to remember when tweet was not handled.
+func (w *Watcher) lastIDBefore(tweets []*Tweet, tweet *Tweet) string {
+	for i := range tweets {
+		if tweets[i] == tweet && i > 0 {
+			return tweets[i-1].ID
+		}
+	}
+	return w.lastID
+}
+
+func (w *Watcher) load() error {
+	if w.loaded {
+		return nil
+	}
+	w.loaded = true
+	if w.options.LastID != "" {
+		w.lastID = w.options.LastID
+		return nil
+	}
+	if w.options.Checkpointer == nil {
+		return nil
+	}
+	checkpoint, err := w.options.Checkpointer.Load(w.operation(), w.source.Query)
+	if err != nil {
+		w.loaded = false
+		return err
+	}
+	if checkpoint != nil {
+		w.lastID = checkpoint.LastID
+	}
+	return nil
+}
+
+// commit remembers lastID if it is newer than the current one.
+func (w *Watcher) commit(lastID string) error {
+	if lastID == "" || (w.lastID != "" && snowflake.Compare(lastID, w.lastID) <= 0) {
+		return nil
+	}
+	w.lastID = lastID
+	if w.options.Checkpointer == nil {
+		return nil
+	}
+	return w.options.Checkpointer.Save(Checkpoint{
+		Operation: w.operation(),
+		Query:     w.source.Query,
+		LastID:    lastID,
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (w *Watcher) operation() string {
+	return "Watch" + w.source.Operation
+}
+
+func (w *Watcher) backoff(failures int) time.Duration {
+	wait := w.options.Interval
+	for i := 0; i < failures && wait < w.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > w.options.MaxBackoff {
+		wait = w.options.MaxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,127 @@
+package twitterscraper
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fakeWatchTimeline serves tweets newest first in pages of maxTweetsNbr, with an old
+// pinned tweet on top of the first page and a promoted tweet on every page.
+type fakeWatchTimeline struct {
+	nextID   int
+	ids      []string
+	requests int
+}
+
+func (f *fakeWatchTimeline) post(n int) {
+	for i := 0; i < n; i++ {
+		f.nextID++
+		f.ids = append([]string{strconv.Itoa(1000 + f.nextID)}, f.ids...)
+	}
+}
+
+func (f *fakeWatchTimeline) fetch(query string, maxTweetsNbr int, cursor string) ([]*Tweet, string, error) {
+	f.requests++
+	offset, _ := strconv.Atoi(cursor)
+	var page []*Tweet
+	if offset == 0 {
+		page = append(page, &Tweet{ID: "999", IsPin: true, EntryType: EntryOrganic})
+	}
+	for i := offset; i < offset+maxTweetsNbr && i < len(f.ids); i++ {
+		page = append(page, &Tweet{ID: f.ids[i], EntryType: EntryOrganic})
+		if i == offset {
+			page = append(page, &Tweet{ID: "5000", EntryType: EntryPromoted})
+		}
+	}
+	next := ""
+	if offset+maxTweetsNbr < len(f.ids) {
+		next = strconv.Itoa(offset + maxTweetsNbr)
+	}
+	return page, next, nil
+}
+
+func newFakeWatcher(timeline *fakeWatchTimeline, options WatchOptions) *Watcher {
+	w := New().NewWatcher(WatchUser("x"), options)
+	w.fetchFunc = timeline.fetch
+	return w
+}
+
+func pollIDs(t *testing.T, w *Watcher) []string {
+	tweets, err := w.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for _, tweet := range tweets {
+		ids = append(ids, tweet.ID)
+	}
+	return ids
+}
+
+func TestWatcherPollPages(t *testing.T) {
+	timeline := &fakeWatchTimeline{}
+	timeline.post(10)
+	checkpointer := NewMemoryCheckpointer()
+	w := newFakeWatcher(timeline, WatchOptions{PageSize: 3, Backfill: 2, Checkpointer: checkpointer})
+
+	// the first poll emits only Backfill latest tweets of the latest page
+	if ids := pollIDs(t, w); !reflect.DeepEqual(ids, []string{"1009", "1010"}) {
+		t.Errorf("Expected backfill of 1009, 1010, got %v", ids)
+	}
+	if timeline.requests != 1 {
+		t.Errorf("Expected first poll fetches one page, got %d requests", timeline.requests)
+	}
+	if w.LastID() != "1010" {
+		t.Errorf("Expected LastID 1010, got %s", w.LastID())
+	}
+
+	// new tweets span several pages, the poll pages back to the last seen one
+	timeline.post(7)
+	timeline.requests = 0
+	expected := []string{"1011", "1012", "1013", "1014", "1015", "1016", "1017"}
+	if ids := pollIDs(t, w); !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected new tweets %v oldest first without pinned and promoted ones, got %v", expected, ids)
+	}
+	if timeline.requests != 3 {
+		t.Errorf("Expected 3 pages to reach the last seen tweet, got %d requests", timeline.requests)
+	}
+
+	checkpoint, err := checkpointer.Load("Watch"+OperationTweets, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint == nil || checkpoint.LastID != "1017" {
+		t.Errorf("Expected saved LastID 1017, got %#v", checkpoint)
+	}
+
+	// a new watcher continues from the saved ID
+	resumed := newFakeWatcher(timeline, WatchOptions{PageSize: 3, Backfill: 2, Checkpointer: checkpointer})
+	if ids := pollIDs(t, resumed); len(ids) != 0 {
+		t.Errorf("Expected no new tweets after resume, got %v", ids)
+	}
+	timeline.post(1)
+	if ids := pollIDs(t, resumed); !reflect.DeepEqual(ids, []string{"1018"}) {
+		t.Errorf("Expected new tweet 1018 after resume, got %v", ids)
+	}
+}
+
+func TestWatcherPollMaxPages(t *testing.T) {
+	timeline := &fakeWatchTimeline{}
+	timeline.post(3)
+	w := newFakeWatcher(timeline, WatchOptions{PageSize: 3, MaxPages: 2, LastID: "1003"})
+
+	// tweets beyond MaxPages are skipped
+	timeline.post(10)
+	expected := []string{"1008", "1009", "1010", "1011", "1012", "1013"}
+	if ids := pollIDs(t, w); !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected the latest %v, got %v", expected, ids)
+	}
+	if timeline.requests != 2 {
+		t.Errorf("Expected MaxPages requests, got %d", timeline.requests)
+	}
+	if w.LastID() != "1013" {
+		t.Errorf("Expected LastID 1013, got %s", w.LastID())
+	}
+}
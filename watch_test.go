@@ -0,0 +1,86 @@
+package twitterscraper_test
+
+import (
+	"context"
+	"testing"
+
+	twitterscraper "github.com/imperatrona/twitter-scraper"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	checkpointer := twitterscraper.NewMemoryCheckpointer()
+	watcher := testScraper.NewWatcher(twitterscraper.WatchUser("x"), twitterscraper.WatchOptions{
+		Backfill:     5,
+		Checkpointer: checkpointer,
+	})
+	tweets, err := watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) != 5 {
+		t.Fatalf("Expected tweets count=%v, got: %v", 5, len(tweets))
+	}
+	for i := 1; i < len(tweets); i++ {
+		if tweets[i-1].TimeParsed.After(tweets[i].TimeParsed) {
+			t.Error("Expected tweets ordered from oldest to newest")
+		}
+	}
+	if watcher.LastID() != tweets[4].ID {
+		t.Errorf("Expected LastID=%v, got: %v", tweets[4].ID, watcher.LastID())
+	}
+
+	checkpoint, err := checkpointer.Load("Watch"+twitterscraper.OperationTweets, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint == nil || checkpoint.LastID != watcher.LastID() {
+		t.Errorf("Expected saved LastID=%v, got: %#v", watcher.LastID(), checkpoint)
+	}
+
+	tweets, err = watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tweet := range tweets {
+		if tweet.ID == checkpoint.LastID {
+			t.Errorf("Expected tweet %v is not emitted twice", tweet.ID)
+		}
+	}
+}
+
+func TestWatcherPagesBack(t *testing.T) {
+	if skipAuthTest {
+		t.Skip("Skipping test due to environment variable")
+	}
+	var ids []string
+	for tweet := range testScraper.GetTweets(context.Background(), "x", 30) {
+		if tweet.Error != nil {
+			t.Fatal(tweet.Error)
+		}
+		ids = append(ids, tweet.ID)
+	}
+	if len(ids) < 30 {
+		t.Fatalf("Expected tweets count=%v, got: %v", 30, len(ids))
+	}
+
+	lastID := ids[len(ids)-1]
+	watcher := testScraper.NewWatcher(twitterscraper.WatchUser("x"), twitterscraper.WatchOptions{
+		LastID:   lastID,
+		PageSize: 10,
+	})
+	tweets, err := watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tweets) < len(ids)-1 {
+		t.Errorf("Expected at least %v new tweets, got: %v", len(ids)-1, len(tweets))
+	}
+	for _, tweet := range tweets {
+		if tweet.ID == lastID {
+			t.Errorf("Expected last seen tweet %v is not emitted", lastID)
+		}
+	}
+}